@@ -49,7 +49,7 @@ func getConnector(ctx context.Context, config *cfg.Miro) (types.ConnectorServer,
 		return nil, err
 	}
 
-	cb, err := connector.New(ctx, config.AccessToken, config.ScimAccessToken)
+	cb, err := connector.New(ctx, config)
 	if err != nil {
 		return nil, err
 	}
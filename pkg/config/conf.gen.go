@@ -4,8 +4,14 @@ package config
 import "reflect"
 
 type Miro struct {
-	AccessToken     string `mapstructure:"miro-access-token"`
-	ScimAccessToken string `mapstructure:"miro-scim-access-token"`
+	AccessToken               string `mapstructure:"miro-access-token"`
+	ScimAccessToken           string `mapstructure:"miro-scim-access-token"`
+	DefaultLicense            string `mapstructure:"miro-default-license"`
+	UseScimForTeamMembership  bool   `mapstructure:"miro-use-scim-for-team-membership"`
+	AllowLastTeamAdminRemoval bool   `mapstructure:"miro-allow-last-team-admin-removal"`
+	ForceDeleteTeams          bool   `mapstructure:"miro-force-delete-teams"`
+	ForceDeleteUsers          bool   `mapstructure:"miro-force-delete-users"`
+	ForceDeleteUserGroups     bool   `mapstructure:"miro-force-delete-user-groups"`
 }
 
 func (c *Miro) findFieldByTag(tagValue string) (any, bool) {
@@ -16,7 +16,47 @@ var (
 		field.WithDescription("Miro SCIM access token. This is used to authenticate with the Miro SCIM API and create users. Assign role to user and revoke role from user."),
 		field.WithDisplayName("Miro SCIM Access Token"),
 	)
-	ConfigurationFields = []field.SchemaField{MiroAccessToken, MiroScimAccessToken}
+	MiroDefaultLicense = field.StringField(
+		"miro-default-license",
+		field.WithDefaultValue("free"),
+		field.WithDescription("License assigned to a user when a license grant is revoked. One of full, occasional, free or free_restricted."),
+		field.WithDisplayName("Default License"),
+	)
+	MiroUseScimForTeamMembership = field.BoolField(
+		"miro-use-scim-for-team-membership",
+		field.WithDescription("Add and remove team members through SCIM group membership instead of team invitations. Requires a SCIM access token."),
+		field.WithDisplayName("Use SCIM For Team Membership"),
+	)
+	MiroAllowLastTeamAdminRemoval = field.BoolField(
+		"miro-allow-last-team-admin-removal",
+		field.WithDescription("Allow revoking or downgrading the last admin of a team."),
+		field.WithDisplayName("Allow Last Team Admin Removal"),
+	)
+	MiroForceDeleteTeams = field.BoolField(
+		"miro-force-delete-teams",
+		field.WithDescription("Delete teams even when they still have projects or boards."),
+		field.WithDisplayName("Force Delete Teams"),
+	)
+	MiroForceDeleteUsers = field.BoolField(
+		"miro-force-delete-users",
+		field.WithDescription("Delete users even when they are still active or own boards."),
+		field.WithDisplayName("Force Delete Users"),
+	)
+	MiroForceDeleteUserGroups = field.BoolField(
+		"miro-force-delete-user-groups",
+		field.WithDescription("Delete user groups even when they are still assigned to teams."),
+		field.WithDisplayName("Force Delete User Groups"),
+	)
+	ConfigurationFields = []field.SchemaField{
+		MiroAccessToken,
+		MiroScimAccessToken,
+		MiroDefaultLicense,
+		MiroUseScimForTeamMembership,
+		MiroAllowLastTeamAdminRemoval,
+		MiroForceDeleteTeams,
+		MiroForceDeleteUsers,
+		MiroForceDeleteUserGroups,
+	}
 )
 
 var (
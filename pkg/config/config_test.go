@@ -29,6 +29,49 @@ func TestValidateConfig(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid config with default license",
+			config: &Miro{
+				AccessToken:    "test-access-token",
+				DefaultLicense: "occasional",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config with scim team membership",
+			config: &Miro{
+				AccessToken:              "test-access-token",
+				ScimAccessToken:          "test-scim-access-token",
+				UseScimForTeamMembership: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config with force delete teams and user groups",
+			config: &Miro{
+				AccessToken:           "test-access-token",
+				ForceDeleteTeams:      true,
+				ForceDeleteUserGroups: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config with last team admin removal allowed",
+			config: &Miro{
+				AccessToken:               "test-access-token",
+				AllowLastTeamAdminRemoval: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid config with force delete users",
+			config: &Miro{
+				AccessToken:      "test-access-token",
+				ScimAccessToken:  "test-scim-access-token",
+				ForceDeleteUsers: true,
+			},
+			wantErr: false,
+		},
 		{
 			name:    "invalid config - missing access token",
 			config:  &Miro{},
@@ -0,0 +1,430 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/conductorone/baton-miro/pkg/miro"
+	config "github.com/conductorone/baton-sdk/pb/c1/config/v1"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	disablePublicLinkSharingAction = "disable_public_link_sharing"
+	restrictTeamInvitationsAction  = "restrict_team_invitations"
+	applyTeamPolicyBaselineAction  = "apply_team_policy_baseline"
+	disableUserAction              = "disable_user"
+	enableUserAction               = "enable_user"
+
+	notAllowedSetting = "not_allowed"
+)
+
+var teamIdArgument = &config.Field{
+	Name:        "team_id",
+	DisplayName: "Team ID",
+	Description: "The ID of the team to update.",
+	IsRequired:  true,
+	Field:       &config.Field_StringField{StringField: &config.StringField{}},
+}
+
+var userIdArgument = &config.Field{
+	Name:        "user_id",
+	DisplayName: "User ID",
+	Description: "The ID of the user to update.",
+	IsRequired:  true,
+	Field:       &config.Field_StringField{StringField: &config.StringField{}},
+}
+
+var actionSchemas = []*v2.BatonActionSchema{
+	{
+		Name:        disablePublicLinkSharingAction,
+		DisplayName: "Disable Public Link Sharing",
+		Description: "Prevents boards of a team from being shared via public link.",
+		Arguments:   []*config.Field{teamIdArgument},
+		ReturnTypes: teamSettingsReturnTypes,
+	},
+	{
+		Name:        restrictTeamInvitationsAction,
+		DisplayName: "Restrict Team Invitations",
+		Description: "Restricts team invitations to members of the organization.",
+		Arguments:   []*config.Field{teamIdArgument},
+		ReturnTypes: teamSettingsReturnTypes,
+	},
+	{
+		Name:        applyTeamPolicyBaselineAction,
+		DisplayName: "Apply Team Policy Baseline",
+		Description: "Applies a named sharing and invitation policy baseline to a team, or to every team when no team is given.",
+		Arguments: []*config.Field{
+			{
+				Name:        "baseline",
+				DisplayName: "Baseline",
+				Description: "The name of the baseline to apply: restricted or internal.",
+				IsRequired:  true,
+				Field:       &config.Field_StringField{StringField: &config.StringField{}},
+			},
+			{
+				Name:        "team_id",
+				DisplayName: "Team ID",
+				Description: "The ID of the team to update. Leave empty to update every team of the organization.",
+				Field:       &config.Field_StringField{StringField: &config.StringField{}},
+			},
+		},
+		ReturnTypes: teamSettingsReturnTypes,
+	},
+	{
+		Name:        disableUserAction,
+		DisplayName: "Disable User",
+		Description: "Deactivates a user through SCIM. The user can no longer sign in, but their content is kept.",
+		Arguments:   []*config.Field{userIdArgument},
+		ReturnTypes: userStatusReturnTypes,
+	},
+	{
+		Name:        enableUserAction,
+		DisplayName: "Enable User",
+		Description: "Reactivates a deactivated user through SCIM.",
+		Arguments:   []*config.Field{userIdArgument},
+		ReturnTypes: userStatusReturnTypes,
+	},
+}
+
+var successReturnType = &config.Field{
+	Name:        "success",
+	DisplayName: "Success",
+	Description: "Whether every change of the action was applied.",
+	Field:       &config.Field_BoolField{BoolField: &config.BoolField{}},
+}
+
+var teamSettingsReturnTypes = []*config.Field{
+	successReturnType,
+	{
+		Name:        "teams",
+		DisplayName: "Teams",
+		Description: "The IDs of the teams that were updated.",
+		Field:       &config.Field_StringSliceField{StringSliceField: &config.StringSliceField{}},
+	},
+	{
+		Name:        "before",
+		DisplayName: "Settings Before",
+		Description: "The settings of each updated team before the change, keyed by team ID.",
+		Field:       &config.Field_StringMapField{StringMapField: &config.StringMapField{}},
+	},
+	{
+		Name:        "after",
+		DisplayName: "Settings After",
+		Description: "The settings of each updated team after the change, keyed by team ID.",
+		Field:       &config.Field_StringMapField{StringMapField: &config.StringMapField{}},
+	},
+}
+
+var userStatusReturnTypes = []*config.Field{
+	successReturnType,
+	{
+		Name:        "user_id",
+		DisplayName: "User ID",
+		Description: "The ID of the user.",
+		Field:       &config.Field_StringField{StringField: &config.StringField{}},
+	},
+	{
+		Name:        "active_before",
+		DisplayName: "Active Before",
+		Description: "Whether the user was active before the change.",
+		Field:       &config.Field_BoolField{BoolField: &config.BoolField{}},
+	},
+	{
+		Name:        "active_after",
+		DisplayName: "Active After",
+		Description: "Whether the user is active after the change.",
+		Field:       &config.Field_BoolField{BoolField: &config.BoolField{}},
+	},
+}
+
+// teamPolicyBaselines are the named team settings baselines that can be applied with the apply_team_policy_baseline action.
+var teamPolicyBaselines = map[string]*miro.TeamSettings{
+	"restricted": {
+		TeamCopyAccessLevelSettings: &miro.TeamCopyAccessLevelSettings{
+			CopyAccessLevel: "team_editors",
+		},
+		TeamInvitationSettings: &miro.TeamInvitationSettings{
+			InviteExternalUsers: notAllowedSetting,
+			WhoCanInvite:        "admins",
+		},
+		TeamSharingPolicySettings: &miro.TeamSharingPolicySettings{
+			DefaultBoardAccess:   "private",
+			SharingOnAccount:     notAllowedSetting,
+			SharingViaPublicLink: notAllowedSetting,
+		},
+	},
+	"internal": {
+		TeamInvitationSettings: &miro.TeamInvitationSettings{
+			InviteExternalUsers: notAllowedSetting,
+		},
+		TeamSharingPolicySettings: &miro.TeamSharingPolicySettings{
+			SharingViaPublicLink: notAllowedSetting,
+		},
+	},
+}
+
+// ListActionSchemas returns the custom actions supported by the connector.
+func (c *Connector) ListActionSchemas(_ context.Context) ([]*v2.BatonActionSchema, annotations.Annotations, error) {
+	return actionSchemas, nil, nil
+}
+
+// GetActionSchema returns the schema of the custom action with the given name.
+func (c *Connector) GetActionSchema(_ context.Context, name string) (*v2.BatonActionSchema, annotations.Annotations, error) {
+	for _, schema := range actionSchemas {
+		if schema.Name == name {
+			return schema, nil, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("baton-miro: unknown action %s", name)
+}
+
+// InvokeAction runs a custom action and returns the state before and after the change.
+// When an action fails part way, the changes it already applied are returned along with the error.
+func (c *Connector) InvokeAction(ctx context.Context, name string, args *structpb.Struct) (string, v2.BatonActionStatus, *structpb.Struct, annotations.Annotations, error) {
+	var (
+		result map[string]interface{}
+		annos  annotations.Annotations
+		err    error
+	)
+	switch name {
+	case disableUserAction, enableUserAction:
+		result, annos, err = c.invokeUserStatusAction(ctx, name, args)
+	default:
+		result, annos, err = c.invokeTeamSettingsAction(ctx, name, args)
+	}
+	if err != nil {
+		if result == nil {
+			return "", v2.BatonActionStatus_BATON_ACTION_STATUS_FAILED, nil, annos, err
+		}
+
+		response, structErr := structpb.NewStruct(result)
+		if structErr != nil {
+			return "", v2.BatonActionStatus_BATON_ACTION_STATUS_FAILED, nil, annos, errors.Join(err, wrapError(structErr, "failed to create action response"))
+		}
+
+		return "", v2.BatonActionStatus_BATON_ACTION_STATUS_FAILED, response, annos, err
+	}
+
+	response, err := structpb.NewStruct(result)
+	if err != nil {
+		return "", v2.BatonActionStatus_BATON_ACTION_STATUS_FAILED, nil, annos, wrapError(err, "failed to create action response")
+	}
+
+	return uuid.NewString(), v2.BatonActionStatus_BATON_ACTION_STATUS_COMPLETE, response, annos, nil
+}
+
+// invokeTeamSettingsAction applies the team settings changes of an action and records the settings before and after the change.
+// If a team fails to update, the teams updated so far are returned together with the error.
+func (c *Connector) invokeTeamSettingsAction(ctx context.Context, name string, args *structpb.Struct) (map[string]interface{}, annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	changes, teamId, err := teamSettingsChanges(name, args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var annos annotations.Annotations
+	teamIds := []string{teamId}
+	if teamId == "" {
+		teamIds, annos, err = c.teamIds(ctx)
+		if err != nil {
+			return nil, annos, wrapError(err, "failed to list teams")
+		}
+	}
+
+	updated := []interface{}{}
+	before := map[string]interface{}{}
+	after := map[string]interface{}{}
+	result := map[string]interface{}{
+		"success": false,
+		"teams":   updated,
+		"before":  before,
+		"after":   after,
+	}
+
+	for _, teamId := range teamIds {
+		var teamBefore, teamAfter map[string]interface{}
+		teamBefore, teamAfter, annos, err = c.updateTeamSettings(ctx, teamId, changes)
+		if err != nil {
+			return result, annos, err
+		}
+
+		l.Info(
+			"baton-miro: updated team settings",
+			zap.String("action", name),
+			zap.String("team_id", teamId),
+		)
+
+		updated = append(updated, teamId)
+		result["teams"] = updated
+		before[teamId] = teamBefore
+		after[teamId] = teamAfter
+	}
+
+	result["success"] = true
+
+	return result, annos, nil
+}
+
+// invokeUserStatusAction deactivates or reactivates a user through SCIM and records the state before and after the change.
+func (c *Connector) invokeUserStatusAction(ctx context.Context, name string, args *structpb.Struct) (map[string]interface{}, annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	userId := args.GetFields()["user_id"].GetStringValue()
+	if userId == "" {
+		return nil, nil, fmt.Errorf("baton-miro: user_id is required for action %s", name)
+	}
+
+	active := name == enableUserAction
+
+	user, annos, err := c.Client.GetUser(ctx, userId)
+	if err != nil {
+		return nil, annos, wrapError(err, "failed to get user")
+	}
+
+	wasActive := user.Active
+	if wasActive != active {
+		if active {
+			user, annos, err = c.Client.ReactivateUser(ctx, userId)
+		} else {
+			user, annos, err = c.Client.DeactivateUser(ctx, userId)
+		}
+		if err != nil {
+			return nil, annos, wrapError(err, "failed to update user status")
+		}
+
+		l.Info(
+			"baton-miro: updated user status",
+			zap.String("action", name),
+			zap.String("user_id", userId),
+			zap.Bool("active", user.Active),
+		)
+	}
+
+	// The state after the change is taken from Miro's response rather than assumed from the request.
+	return map[string]interface{}{
+		"success":       user.Active == active,
+		"user_id":       userId,
+		"active_before": wasActive,
+		"active_after":  user.Active,
+	}, annos, nil
+}
+
+// GetActionStatus is not supported, since actions complete before InvokeAction returns.
+func (c *Connector) GetActionStatus(_ context.Context, id string) (v2.BatonActionStatus, string, *structpb.Struct, annotations.Annotations, error) {
+	return v2.BatonActionStatus_BATON_ACTION_STATUS_UNSPECIFIED, "", nil, nil, fmt.Errorf("baton-miro: actions run synchronously, no status for action %s", id)
+}
+
+// teamSettingsChanges returns the team settings to apply for an action, and the team to apply them to.
+// An empty team ID means the changes apply to every team.
+func teamSettingsChanges(name string, args *structpb.Struct) (*miro.TeamSettings, string, error) {
+	teamId := args.GetFields()["team_id"].GetStringValue()
+
+	switch name {
+	case disablePublicLinkSharingAction:
+		if teamId == "" {
+			return nil, "", fmt.Errorf("baton-miro: team_id is required for action %s", name)
+		}
+
+		return &miro.TeamSettings{
+			TeamSharingPolicySettings: &miro.TeamSharingPolicySettings{
+				SharingViaPublicLink: notAllowedSetting,
+			},
+		}, teamId, nil
+	case restrictTeamInvitationsAction:
+		if teamId == "" {
+			return nil, "", fmt.Errorf("baton-miro: team_id is required for action %s", name)
+		}
+
+		return &miro.TeamSettings{
+			TeamInvitationSettings: &miro.TeamInvitationSettings{
+				InviteExternalUsers: notAllowedSetting,
+			},
+		}, teamId, nil
+	case applyTeamPolicyBaselineAction:
+		baselineName := args.GetFields()["baseline"].GetStringValue()
+		baseline, ok := teamPolicyBaselines[baselineName]
+		if !ok {
+			return nil, "", fmt.Errorf("baton-miro: unknown team policy baseline %q", baselineName)
+		}
+
+		return baseline, teamId, nil
+	default:
+		return nil, "", fmt.Errorf("baton-miro: unknown action %s", name)
+	}
+}
+
+// teamIds returns the IDs of every team of the organization.
+func (c *Connector) teamIds(ctx context.Context) ([]string, annotations.Annotations, error) {
+	var teamIds []string
+	cursor := ""
+	for {
+		response, annos, err := c.Client.GetTeams(ctx, c.OrganizationId, cursor, resourcePageSize)
+		if err != nil {
+			return nil, annos, err
+		}
+
+		for _, team := range response.Data {
+			teamIds = append(teamIds, team.Id)
+		}
+
+		if response.Cursor == "" {
+			return teamIds, annos, nil
+		}
+		cursor = response.Cursor
+	}
+}
+
+// updateTeamSettings applies changes to the settings of a team and returns the settings before and after the change.
+func (c *Connector) updateTeamSettings(
+	ctx context.Context,
+	teamId string,
+	changes *miro.TeamSettings,
+) (map[string]interface{}, map[string]interface{}, annotations.Annotations, error) {
+	before, annos, err := c.Client.GetTeamSettings(ctx, c.OrganizationId, teamId)
+	if err != nil {
+		return nil, nil, annos, wrapError(err, "failed to get team settings")
+	}
+
+	after, annos, err := c.Client.UpdateTeamSettings(ctx, c.OrganizationId, teamId, changes)
+	if err != nil {
+		return nil, nil, annos, wrapError(err, "failed to update team settings")
+	}
+
+	beforeValue, err := teamSettingsValue(before)
+	if err != nil {
+		return nil, nil, annos, wrapError(err, "failed to record team settings")
+	}
+
+	afterValue, err := teamSettingsValue(after)
+	if err != nil {
+		return nil, nil, annos, wrapError(err, "failed to record team settings")
+	}
+
+	return beforeValue, afterValue, annos, nil
+}
+
+// teamSettingsValue converts team settings into a value that can be stored in a structpb.Struct.
+func teamSettingsValue(settings *miro.TeamSettings) (map[string]interface{}, error) {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	var value map[string]interface{}
+	err = json.Unmarshal(data, &value)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
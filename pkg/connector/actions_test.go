@@ -0,0 +1,265 @@
+package connector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/conductorone/baton-miro/pkg/miro"
+	"github.com/conductorone/baton-miro/test"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// TestConnector_GetActionSchema tests looking up custom action schemas.
+func TestConnector_GetActionSchema(t *testing.T) {
+	c := &Connector{}
+
+	schemas, _, err := c.ListActionSchemas(context.Background())
+	if err != nil {
+		t.Fatalf("ListActionSchemas() error = %v", err)
+	}
+
+	for _, schema := range schemas {
+		got, _, err := c.GetActionSchema(context.Background(), schema.Name)
+		if err != nil {
+			t.Fatalf("GetActionSchema(%s) error = %v", schema.Name, err)
+		}
+		if got != schema {
+			t.Errorf("GetActionSchema(%s) returned a different schema", schema.Name)
+		}
+	}
+
+	if _, _, err := c.GetActionSchema(context.Background(), "unknown"); err == nil {
+		t.Errorf("GetActionSchema() expected error for unknown action")
+	}
+}
+
+// TestTeamSettingsChanges tests the team settings applied by each custom action.
+func TestTeamSettingsChanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     string
+		args       map[string]interface{}
+		wantTeamID string
+		wantErr    bool
+	}{
+		{name: "disable public link sharing", action: disablePublicLinkSharingAction, args: map[string]interface{}{"team_id": testTeamID}, wantTeamID: testTeamID},
+		{name: "disable public link sharing without team", action: disablePublicLinkSharingAction, args: map[string]interface{}{}, wantErr: true},
+		{name: "restrict invitations", action: restrictTeamInvitationsAction, args: map[string]interface{}{"team_id": testTeamID}, wantTeamID: testTeamID},
+		{name: "baseline for every team", action: applyTeamPolicyBaselineAction, args: map[string]interface{}{"baseline": "restricted"}},
+		{name: "unknown baseline", action: applyTeamPolicyBaselineAction, args: map[string]interface{}{"baseline": "open"}, wantErr: true},
+		{name: "unknown action", action: "unknown", args: map[string]interface{}{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := structpb.NewStruct(tt.args)
+			if err != nil {
+				t.Fatalf("NewStruct() error = %v", err)
+			}
+
+			changes, teamID, err := teamSettingsChanges(tt.action, args)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("teamSettingsChanges() expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("teamSettingsChanges() error = %v", err)
+			}
+			if changes == nil {
+				t.Fatalf("teamSettingsChanges() returned no changes")
+			}
+			if teamID != tt.wantTeamID {
+				t.Errorf("teamSettingsChanges() teamID = %v, want %v", teamID, tt.wantTeamID)
+			}
+		})
+	}
+}
+
+// TestTeamSettingsValue tests that team settings can be recorded in an action response.
+func TestTeamSettingsValue(t *testing.T) {
+	value, err := teamSettingsValue(&miro.TeamSettings{
+		TeamId: testTeamID,
+		TeamSharingPolicySettings: &miro.TeamSharingPolicySettings{
+			AllowListedDomains:   []string{"example.com"},
+			SharingViaPublicLink: notAllowedSetting,
+		},
+	})
+	if err != nil {
+		t.Fatalf("teamSettingsValue() error = %v", err)
+	}
+
+	if _, err := structpb.NewStruct(map[string]interface{}{"after": value}); err != nil {
+		t.Fatalf("NewStruct() error = %v", err)
+	}
+
+	sharing, ok := value["teamSharingPolicySettings"].(map[string]interface{})
+	if !ok || sharing["sharingViaPublicLink"] != notAllowedSetting {
+		t.Errorf("teamSettingsValue() sharingViaPublicLink = %v, want %v", sharing["sharingViaPublicLink"], notAllowedSetting)
+	}
+}
+
+// TestConnector_InvokeUserStatusActionRequiresUser tests that user status actions require a user ID.
+func TestConnector_InvokeUserStatusActionRequiresUser(t *testing.T) {
+	c := &Connector{}
+
+	for _, action := range []string{disableUserAction, enableUserAction} {
+		args, err := structpb.NewStruct(map[string]interface{}{})
+		if err != nil {
+			t.Fatalf("NewStruct() error = %v", err)
+		}
+
+		_, status, _, _, err := c.InvokeAction(context.Background(), action, args)
+		if err == nil {
+			t.Errorf("InvokeAction(%s) expected error without user_id", action)
+		}
+
+		if status != v2.BatonActionStatus_BATON_ACTION_STATUS_FAILED {
+			t.Errorf("InvokeAction(%s) status = %v, want %v", action, status, v2.BatonActionStatus_BATON_ACTION_STATUS_FAILED)
+		}
+	}
+}
+
+// TestConnector_InvokeTeamSettingsActionPartialResults tests that a failing team keeps the results of the teams already updated.
+func TestConnector_InvokeTeamSettingsActionPartialResults(t *testing.T) {
+	teamsUrl := "/v2/orgs/" + test.MockOrgID + "/teams"
+	client, _ := test.NewMockServerClient(t, map[string]test.MockResponse{
+		"GET " + teamsUrl:                          {File: "teams_success.json"},
+		"GET " + teamsUrl + "/team-123/settings":   {File: "team_settings_success.json"},
+		"PATCH " + teamsUrl + "/team-123/settings": {File: "team_settings_success.json"},
+		"GET " + teamsUrl + "/team-456/settings":   {File: "team_settings_success.json"},
+		"PATCH " + teamsUrl + "/team-456/settings": {Status: 403},
+	})
+	c := &Connector{Client: client, OrganizationId: test.MockOrgID}
+
+	args, err := structpb.NewStruct(map[string]interface{}{"baseline": "internal"})
+	if err != nil {
+		t.Fatalf("NewStruct() error = %v", err)
+	}
+
+	_, status, response, _, err := c.InvokeAction(context.Background(), applyTeamPolicyBaselineAction, args)
+	if err == nil {
+		t.Fatal("InvokeAction() expected error when a team fails to update")
+	}
+
+	if status != v2.BatonActionStatus_BATON_ACTION_STATUS_FAILED {
+		t.Errorf("InvokeAction() status = %v, want %v", status, v2.BatonActionStatus_BATON_ACTION_STATUS_FAILED)
+	}
+
+	fields := response.GetFields()
+	if fields["success"].GetBoolValue() {
+		t.Error("InvokeAction() success = true, want false")
+	}
+
+	teams := fields["teams"].GetListValue().GetValues()
+	if len(teams) != 1 || teams[0].GetStringValue() != testTeamID {
+		t.Errorf("InvokeAction() teams = %v, want [%s]", teams, testTeamID)
+	}
+
+	for _, key := range []string{"before", "after"} {
+		settings := fields[key].GetStructValue().GetFields()
+		if _, ok := settings[testTeamID]; !ok || len(settings) != 1 {
+			t.Errorf("InvokeAction() %s = %v, want settings of %s only", key, settings, testTeamID)
+		}
+	}
+}
+
+// TestConnector_InvokeUserStatusAction tests disabling and enabling users against the mock SCIM API.
+func TestConnector_InvokeUserStatusAction(t *testing.T) {
+	userUrl := "/api/v1/scim/Users/" + testUserID
+
+	tests := []struct {
+		name             string
+		action           string
+		routes           map[string]test.MockResponse
+		wantPatch        bool
+		wantSuccess      bool
+		wantActiveBefore bool
+		wantActiveAfter  bool
+	}{
+		{
+			name:   "disable active user",
+			action: disableUserAction,
+			routes: map[string]test.MockResponse{
+				"GET " + userUrl:   {File: "scim_user_success.json"},
+				"PATCH " + userUrl: {File: "scim_user_inactive_success.json"},
+			},
+			wantPatch:        true,
+			wantSuccess:      true,
+			wantActiveBefore: true,
+			wantActiveAfter:  false,
+		},
+		{
+			name:   "enable deactivated user",
+			action: enableUserAction,
+			routes: map[string]test.MockResponse{
+				"GET " + userUrl:   {File: "scim_user_inactive_success.json"},
+				"PATCH " + userUrl: {File: "scim_user_success.json"},
+			},
+			wantPatch:        true,
+			wantSuccess:      true,
+			wantActiveBefore: false,
+			wantActiveAfter:  true,
+		},
+		{
+			name:   "enable already active user",
+			action: enableUserAction,
+			routes: map[string]test.MockResponse{
+				"GET " + userUrl: {File: "scim_user_success.json"},
+			},
+			wantSuccess:      true,
+			wantActiveBefore: true,
+			wantActiveAfter:  true,
+		},
+		{
+			name:   "disable ignored by Miro",
+			action: disableUserAction,
+			routes: map[string]test.MockResponse{
+				"GET " + userUrl:   {File: "scim_user_success.json"},
+				"PATCH " + userUrl: {File: "scim_user_success.json"},
+			},
+			wantPatch:        true,
+			wantSuccess:      false,
+			wantActiveBefore: true,
+			wantActiveAfter:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := test.NewMockServerClient(t, tt.routes)
+			c := &Connector{Client: client, OrganizationId: test.MockOrgID}
+
+			args, err := structpb.NewStruct(map[string]interface{}{"user_id": testUserID})
+			if err != nil {
+				t.Fatalf("NewStruct() error = %v", err)
+			}
+
+			_, status, response, _, err := c.InvokeAction(context.Background(), tt.action, args)
+			if err != nil {
+				t.Fatalf("InvokeAction() error = %v", err)
+			}
+
+			if status != v2.BatonActionStatus_BATON_ACTION_STATUS_COMPLETE {
+				t.Errorf("InvokeAction() status = %v, want %v", status, v2.BatonActionStatus_BATON_ACTION_STATUS_COMPLETE)
+			}
+
+			if server.Received("PATCH "+userUrl) != tt.wantPatch {
+				t.Errorf("InvokeAction() requests = %v, want PATCH %v", server.Requests(), tt.wantPatch)
+			}
+
+			fields := response.GetFields()
+			if got := fields["success"].GetBoolValue(); got != tt.wantSuccess {
+				t.Errorf("InvokeAction() success = %v, want %v", got, tt.wantSuccess)
+			}
+			if got := fields["active_before"].GetBoolValue(); got != tt.wantActiveBefore {
+				t.Errorf("InvokeAction() active_before = %v, want %v", got, tt.wantActiveBefore)
+			}
+			if got := fields["active_after"].GetBoolValue(); got != tt.wantActiveAfter {
+				t.Errorf("InvokeAction() active_after = %v, want %v", got, tt.wantActiveAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conductorone/baton-miro/pkg/miro"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+type boardBuilder struct {
+	resourceType   *v2.ResourceType
+	client         *miro.Client
+	organizationId string
+}
+
+const (
+	ownerBoardRole     = "owner"
+	coownerBoardRole   = "coowner"
+	editorBoardRole    = "editor"
+	commenterBoardRole = "commenter"
+	viewerBoardRole    = "viewer"
+)
+
+var boardRoles = []string{
+	ownerBoardRole,
+	coownerBoardRole,
+	editorBoardRole,
+	commenterBoardRole,
+	viewerBoardRole,
+}
+
+// ResourceType returns the resource type for the board builder.
+func (o *boardBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return boardResourceType
+}
+
+func boardResource(board *miro.Board, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	profile := map[string]interface{}{
+		"name":        board.Name,
+		"id":          board.Id,
+		"description": board.Description,
+		"view_link":   board.ViewLink,
+	}
+
+	if board.Owner != nil {
+		profile["owner_id"] = board.Owner.Id
+		profile["owner_name"] = board.Owner.Name
+	}
+
+	if board.Team != nil {
+		profile["team_id"] = board.Team.Id
+	}
+
+	boardTraitOptions := []rs.GroupTraitOption{
+		rs.WithGroupProfile(profile),
+	}
+	resource, err := rs.NewGroupResource(
+		board.Name,
+		boardResourceType,
+		board.Id,
+		boardTraitOptions,
+		rs.WithParentResourceID(parentResourceID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// newBoardBuilder creates a new board builder.
+func newBoardBuilder(client *miro.Client, organizationId string) *boardBuilder {
+	return &boardBuilder{
+		resourceType:   boardResourceType,
+		client:         client,
+		organizationId: organizationId,
+	}
+}
+
+// List returns the boards of a team.
+func (o *boardBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	if parentResourceID == nil {
+		return nil, "", nil, nil
+	}
+
+	bag, token, err := parsePageToken(pToken.Token, &v2.ResourceId{ResourceType: o.resourceType.Id})
+	if err != nil {
+		return nil, "", nil, wrapError(err, "failed to parse page token")
+	}
+
+	offset, err := parseOffset(token)
+	if err != nil {
+		return nil, "", nil, wrapError(err, "failed to parse page token")
+	}
+
+	response, annos, err := o.client.GetBoards(ctx, parentResourceID.Resource, offset, resourcePageSize)
+	if err != nil {
+		return nil, "", annos, wrapError(err, "failed to get boards")
+	}
+
+	var resources []*v2.Resource
+	for _, board := range response.Data {
+		board := board
+		resource, err := boardResource(&board, parentResourceID)
+		if err != nil {
+			return nil, "", annos, wrapError(err, "failed to create board resource")
+		}
+
+		resources = append(resources, resource)
+	}
+
+	next := nextOffset(response.Offset, response.Size, response.Total)
+	if next == "" {
+		return resources, "", annos, nil
+	}
+
+	nextCursor, err := handleNextPage(bag, next)
+	if err != nil {
+		return nil, "", annos, wrapError(err, "failed to create next page cursor")
+	}
+
+	return resources, nextCursor, annos, nil
+}
+
+// Entitlements returns the board roles as entitlements.
+func (o *boardBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	var rv []*v2.Entitlement
+
+	for _, role := range boardRoles {
+		assigmentOptions := []ent.EntitlementOption{
+			ent.WithDescription(fmt.Sprintf("Has %s role on %s board", role, resource.DisplayName)),
+			ent.WithDisplayName(fmt.Sprintf("%s board role %s", resource.DisplayName, role)),
+		}
+		// Board ownership cannot be granted through the API, so the owner role is sync-only.
+		if role != ownerBoardRole {
+			assigmentOptions = append(assigmentOptions, ent.WithGrantableTo(userResourceType))
+		}
+
+		entitlement := ent.NewAssignmentEntitlement(resource, role, assigmentOptions...)
+		rv = append(rv, entitlement)
+	}
+
+	return rv, "", nil, nil
+}
+
+// Grants returns a grant for each member of a board.
+func (o *boardBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	bag, token, err := parsePageToken(pToken.Token, &v2.ResourceId{ResourceType: o.resourceType.Id})
+	if err != nil {
+		return nil, "", nil, wrapError(err, "failed to parse page token")
+	}
+
+	offset, err := parseOffset(token)
+	if err != nil {
+		return nil, "", nil, wrapError(err, "failed to parse page token")
+	}
+
+	response, annos, err := o.client.GetBoardMembers(ctx, resource.Id.Resource, offset, resourcePageSize)
+	if err != nil {
+		return nil, "", annos, wrapError(err, "failed to get board members")
+	}
+
+	var grants []*v2.Grant
+	for _, member := range response.Data {
+		if !contains(boardRoles, member.Role) {
+			continue
+		}
+
+		userResourceId := &v2.ResourceId{
+			ResourceType: userResourceType.Id,
+			Resource:     member.Id,
+		}
+
+		g := grant.NewGrant(resource, member.Role, userResourceId)
+		grants = append(grants, g)
+	}
+
+	next := nextOffset(response.Offset, response.Size, response.Total)
+	if next == "" {
+		return grants, "", annos, nil
+	}
+
+	nextCursor, err := handleNextPage(bag, next)
+	if err != nil {
+		return nil, "", annos, wrapError(err, "failed to create next page cursor")
+	}
+
+	return grants, nextCursor, annos, nil
+}
+
+// Grant shares a board with a user, or changes the role of an existing board member in place.
+func (o *boardBuilder) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	if principal.Id.ResourceType != userResourceType.Id {
+		err := fmt.Errorf("baton-miro: only users can be granted board roles")
+
+		l.Warn(
+			err.Error(),
+			zap.String("principal_id", principal.Id.Resource),
+			zap.String("principal_type", principal.Id.ResourceType),
+		)
+
+		return nil, nil, err
+	}
+
+	role, err := parseRoleFromEntitlementID(entitlement.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !contains(boardRoles, role) {
+		return nil, nil, fmt.Errorf("baton-miro: invalid board role %s", role)
+	}
+	if role == ownerBoardRole {
+		return nil, nil, fmt.Errorf("baton-miro: board ownership cannot be granted")
+	}
+
+	boardId := entitlement.Resource.Id.Resource
+	userId := principal.Id.Resource
+
+	member, annos, err := o.client.GetBoardMember(ctx, boardId, userId)
+	if err != nil && !isNotFoundError(err) {
+		return nil, annos, wrapError(err, "failed to get board member")
+	}
+
+	switch {
+	case member == nil:
+		var user *miro.User
+		user, annos, err = o.client.GetOrganizationMember(ctx, o.organizationId, userId)
+		if err != nil {
+			return nil, annos, wrapError(err, "failed to get user")
+		}
+
+		_, annos, err = o.client.ShareBoard(ctx, boardId, user.Email, role)
+		if err != nil {
+			return nil, annos, wrapError(err, "failed to share board with user")
+		}
+	case member.Role == role:
+		return nil, annotations.New(&v2.GrantAlreadyExists{}), nil
+	case member.Role == ownerBoardRole:
+		return nil, annos, fmt.Errorf("baton-miro: the role of the board owner cannot be changed")
+	default:
+		_, annos, err = o.client.UpdateBoardMember(ctx, boardId, userId, role)
+		if err != nil {
+			return nil, annos, wrapError(err, "failed to update board member role")
+		}
+	}
+
+	g := grant.NewGrant(entitlement.Resource, role, principal.Id)
+	return []*v2.Grant{g}, annos, nil
+}
+
+// Revoke removes a user from a board when they still hold the revoked role.
+func (o *boardBuilder) Revoke(ctx context.Context, g *v2.Grant) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	entitlement := g.Entitlement
+	principal := g.Principal
+
+	if principal.Id.ResourceType != userResourceType.Id {
+		err := fmt.Errorf("baton-miro: only users can be revoked from board")
+
+		l.Warn(
+			err.Error(),
+			zap.String("principal_id", principal.Id.Resource),
+			zap.String("principal_type", principal.Id.ResourceType),
+		)
+
+		return nil, err
+	}
+
+	role, err := parseRoleFromEntitlementID(entitlement.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	boardId := entitlement.Resource.Id.Resource
+	userId := principal.Id.Resource
+
+	member, annos, err := o.client.GetBoardMember(ctx, boardId, userId)
+	if err != nil {
+		if isNotFoundError(err) {
+			return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+		}
+		return annos, wrapError(err, "failed to get board member")
+	}
+
+	if member.Role != role {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+
+	if role == ownerBoardRole {
+		return nil, fmt.Errorf("baton-miro: the board owner cannot be removed from the board")
+	}
+
+	annos, err = o.client.RemoveBoardMember(ctx, boardId, userId)
+	if err != nil {
+		return annos, wrapError(err, "failed to remove user from board")
+	}
+
+	return annos, nil
+}
@@ -0,0 +1,385 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/conductorone/baton-miro/pkg/miro"
+	"github.com/conductorone/baton-miro/test"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+const (
+	testBoardID = "board-123"
+)
+
+// TestBoardResource tests the board resource.
+func TestBoardResource(t *testing.T) {
+	board := &miro.Board{
+		Id:   testBoardID,
+		Name: "Architecture Review",
+		Type: "board",
+		Owner: &miro.BoardUser{
+			Id:   testUserID,
+			Name: "John Doe",
+			Type: "user",
+		},
+	}
+	parent := &v2.ResourceId{ResourceType: teamResourceType.Id, Resource: testTeamID}
+
+	resource, err := boardResource(board, parent)
+	if err != nil {
+		t.Fatalf("boardResource() error = %v", err)
+	}
+
+	if resource.DisplayName != "Architecture Review" {
+		t.Errorf("boardResource() DisplayName = %v, want %v", resource.DisplayName, "Architecture Review")
+	}
+
+	if resource.Id.ResourceType != boardResourceType.Id {
+		t.Errorf("boardResource() Id.ResourceType = %v, want %v", resource.Id.ResourceType, boardResourceType.Id)
+	}
+
+	if resource.ParentResourceId.Resource != testTeamID {
+		t.Errorf("boardResource() ParentResourceId.Resource = %v, want %v", resource.ParentResourceId.Resource, testTeamID)
+	}
+
+	groupTrait, err := rs.GetGroupTrait(resource)
+	if err != nil {
+		t.Fatalf("GetGroupTrait() error = %v", err)
+	}
+
+	ownerID, ok := rs.GetProfileStringValue(groupTrait.Profile, "owner_id")
+	if !ok || ownerID != testUserID {
+		t.Errorf("boardResource() profile owner_id = %v, want %v", ownerID, testUserID)
+	}
+}
+
+// TestBoardMockData tests the board mock data.
+func TestBoardMockData(t *testing.T) {
+	mockData := test.ReadFile("boards_success.json")
+
+	var response miro.GetBoardsResponse
+	err := json.Unmarshal([]byte(mockData), &response)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal mock boards data: %v", err)
+	}
+
+	if len(response.Data) != 2 {
+		t.Errorf("Expected 2 boards in mock data, got %d", len(response.Data))
+	}
+
+	if response.Data[0].Id != testBoardID {
+		t.Errorf("Expected first board ID to be %s, got %s", testBoardID, response.Data[0].Id)
+	}
+
+	if response.Data[0].Owner == nil || response.Data[0].Owner.Id != testUserID {
+		t.Errorf("Expected first board owner to be %s", testUserID)
+	}
+}
+
+// TestBoardMembersMockData tests the board members mock data.
+func TestBoardMembersMockData(t *testing.T) {
+	mockData := test.ReadFile("board_members_success.json")
+
+	var response miro.GetBoardMembersResponse
+	err := json.Unmarshal([]byte(mockData), &response)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal mock board members data: %v", err)
+	}
+
+	if len(response.Data) != 2 {
+		t.Errorf("Expected 2 members in mock data, got %d", len(response.Data))
+	}
+
+	for _, member := range response.Data {
+		if !contains(boardRoles, member.Role) {
+			t.Errorf("Unexpected board role %s", member.Role)
+		}
+	}
+}
+
+// TestBoardBuilder_Entitlements tests the entitlements for a board.
+func TestBoardBuilder_Entitlements(t *testing.T) {
+	builder := &boardBuilder{
+		resourceType: boardResourceType,
+	}
+
+	resource := &v2.Resource{
+		Id: &v2.ResourceId{
+			ResourceType: boardResourceType.Id,
+			Resource:     testBoardID,
+		},
+		DisplayName: "Architecture Review",
+	}
+
+	entitlements, nextPage, _, err := builder.Entitlements(context.Background(), resource, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("Entitlements() error = %v", err)
+	}
+
+	if nextPage != "" {
+		t.Errorf("Entitlements() nextPage = %v, want empty string", nextPage)
+	}
+
+	if len(entitlements) != len(boardRoles) {
+		t.Errorf("Entitlements() count = %v, want %v", len(entitlements), len(boardRoles))
+	}
+
+	for i, role := range boardRoles {
+		if entitlements[i].Slug != role {
+			t.Errorf("Entitlements()[%d].Slug = %v, want %v", i, entitlements[i].Slug, role)
+		}
+
+		grantable := len(entitlements[i].GrantableTo) > 0
+		if grantable != (role != ownerBoardRole) {
+			t.Errorf("Entitlements()[%d] grantable = %v for role %v", i, grantable, role)
+		}
+	}
+}
+
+// TestBoardBuilder_ListWithoutParent tests that boards are only listed under a team.
+func TestBoardBuilder_ListWithoutParent(t *testing.T) {
+	builder := &boardBuilder{
+		resourceType: boardResourceType,
+	}
+
+	resources, nextPage, _, err := builder.List(context.Background(), nil, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(resources) != 0 || nextPage != "" {
+		t.Errorf("List() without parent = %v resources, next page %q; want none", len(resources), nextPage)
+	}
+}
+
+// TestNextOffset tests the offset pagination helper.
+func TestNextOffset(t *testing.T) {
+	tests := []struct {
+		name     string
+		offset   int32
+		size     int32
+		total    int32
+		expected string
+	}{
+		{name: "more pages", offset: 0, size: 50, total: 120, expected: "50"},
+		{name: "last page", offset: 100, size: 20, total: 120, expected: ""},
+		{name: "empty page", offset: 0, size: 0, total: 10, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextOffset(tt.offset, tt.size, tt.total); got != tt.expected {
+				t.Errorf("nextOffset() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParseRoleFromEntitlementID tests parsing the board role from an entitlement ID.
+func TestParseRoleFromEntitlementID(t *testing.T) {
+	tests := []struct {
+		name          string
+		entitlementID string
+		expected      string
+		wantErr       bool
+	}{
+		{name: "board role", entitlementID: "board:board-123:editor", expected: editorBoardRole},
+		{name: "missing role", entitlementID: "board:board-123", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, err := parseRoleFromEntitlementID(tt.entitlementID)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseRoleFromEntitlementID() expected error for %s", tt.entitlementID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseRoleFromEntitlementID() error = %v", err)
+			}
+			if role != tt.expected {
+				t.Errorf("parseRoleFromEntitlementID() = %v, want %v", role, tt.expected)
+			}
+		})
+	}
+}
+
+// TestBoardBuilder_Grant tests sharing boards and changing board roles against the mock API.
+func TestBoardBuilder_Grant(t *testing.T) {
+	membersUrl := "/v2/boards/" + testBoardID + "/members"
+	memberUrl := membersUrl + "/" + testUserID
+	orgMemberUrl := "/v2/orgs/" + test.MockOrgID + "/members/" + testUserID
+
+	tests := []struct {
+		name             string
+		role             string
+		routes           map[string]test.MockResponse
+		wantErr          bool
+		wantAlreadyExist bool
+		wantRequests     []string
+		unwantedRequests []string
+	}{
+		{
+			name: "share with non-member",
+			role: editorBoardRole,
+			routes: map[string]test.MockResponse{
+				"GET " + orgMemberUrl: {File: "organization_user_success.json"},
+				"POST " + membersUrl:  {Status: 201, File: "board_share_success.json"},
+			},
+			wantRequests:     []string{"POST " + membersUrl},
+			unwantedRequests: []string{"PATCH " + memberUrl},
+		},
+		{
+			name: "re-grant existing role",
+			role: editorBoardRole,
+			routes: map[string]test.MockResponse{
+				"GET " + memberUrl: {File: "board_member_editor_success.json"},
+			},
+			wantAlreadyExist: true,
+			unwantedRequests: []string{"POST " + membersUrl, "PATCH " + memberUrl},
+		},
+		{
+			name: "change role of member",
+			role: viewerBoardRole,
+			routes: map[string]test.MockResponse{
+				"GET " + memberUrl:   {File: "board_member_editor_success.json"},
+				"PATCH " + memberUrl: {File: "board_member_editor_success.json"},
+			},
+			wantRequests:     []string{"PATCH " + memberUrl},
+			unwantedRequests: []string{"POST " + membersUrl},
+		},
+		{
+			name: "change role of owner",
+			role: editorBoardRole,
+			routes: map[string]test.MockResponse{
+				"GET " + memberUrl: {File: "board_member_owner_success.json"},
+			},
+			wantErr:          true,
+			unwantedRequests: []string{"POST " + membersUrl, "PATCH " + memberUrl},
+		},
+		{
+			name:             "grant owner role",
+			role:             ownerBoardRole,
+			wantErr:          true,
+			unwantedRequests: []string{"GET " + memberUrl, "POST " + membersUrl, "PATCH " + memberUrl},
+		},
+	}
+
+	board, err := boardResource(&miro.Board{Id: testBoardID, Name: "Architecture Review"}, &v2.ResourceId{ResourceType: teamResourceType.Id, Resource: testTeamID})
+	if err != nil {
+		t.Fatalf("boardResource() error = %v", err)
+	}
+	principal := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: testUserID}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := test.NewMockServerClient(t, tt.routes)
+			builder := newBoardBuilder(client, test.MockOrgID)
+
+			entitlement := &v2.Entitlement{Id: "board:" + testBoardID + ":" + tt.role, Resource: board}
+			grants, annos, err := builder.Grant(context.Background(), principal, entitlement)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Grant() error = %v, wantErr %v, requests = %v", err, tt.wantErr, server.Requests())
+			}
+
+			if got := annos.Contains(&v2.GrantAlreadyExists{}); got != tt.wantAlreadyExist {
+				t.Errorf("Grant() GrantAlreadyExists = %v, want %v", got, tt.wantAlreadyExist)
+			}
+
+			if !tt.wantErr && !tt.wantAlreadyExist && len(grants) != 1 {
+				t.Errorf("Grant() returned %d grants, want 1", len(grants))
+			}
+
+			for _, request := range tt.wantRequests {
+				if !server.Received(request) {
+					t.Errorf("Grant() requests = %v, want %s", server.Requests(), request)
+				}
+			}
+
+			for _, request := range tt.unwantedRequests {
+				if server.Received(request) {
+					t.Errorf("Grant() requests = %v, did not want %s", server.Requests(), request)
+				}
+			}
+		})
+	}
+}
+
+// TestBoardBuilder_Revoke tests removing users from boards against the mock API.
+func TestBoardBuilder_Revoke(t *testing.T) {
+	memberUrl := "/v2/boards/" + testBoardID + "/members/" + testUserID
+
+	tests := []struct {
+		name               string
+		role               string
+		routes             map[string]test.MockResponse
+		wantErr            bool
+		wantAlreadyRevoked bool
+		wantDelete         bool
+	}{
+		{
+			name: "remove member",
+			role: editorBoardRole,
+			routes: map[string]test.MockResponse{
+				"GET " + memberUrl:    {File: "board_member_editor_success.json"},
+				"DELETE " + memberUrl: {Status: 204},
+			},
+			wantDelete: true,
+		},
+		{
+			name:               "re-revoke removed member",
+			role:               editorBoardRole,
+			wantAlreadyRevoked: true,
+		},
+		{
+			name: "revoke role the member no longer holds",
+			role: viewerBoardRole,
+			routes: map[string]test.MockResponse{
+				"GET " + memberUrl: {File: "board_member_editor_success.json"},
+			},
+			wantAlreadyRevoked: true,
+		},
+		{
+			name: "remove owner",
+			role: ownerBoardRole,
+			routes: map[string]test.MockResponse{
+				"GET " + memberUrl: {File: "board_member_owner_success.json"},
+			},
+			wantErr: true,
+		},
+	}
+
+	board, err := boardResource(&miro.Board{Id: testBoardID, Name: "Architecture Review"}, &v2.ResourceId{ResourceType: teamResourceType.Id, Resource: testTeamID})
+	if err != nil {
+		t.Fatalf("boardResource() error = %v", err)
+	}
+	principal := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: testUserID}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := test.NewMockServerClient(t, tt.routes)
+			builder := newBoardBuilder(client, test.MockOrgID)
+
+			entitlement := &v2.Entitlement{Id: "board:" + testBoardID + ":" + tt.role, Resource: board}
+			annos, err := builder.Revoke(context.Background(), &v2.Grant{Entitlement: entitlement, Principal: principal})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Revoke() error = %v, wantErr %v, requests = %v", err, tt.wantErr, server.Requests())
+			}
+
+			if got := annos.Contains(&v2.GrantAlreadyRevoked{}); got != tt.wantAlreadyRevoked {
+				t.Errorf("Revoke() GrantAlreadyRevoked = %v, want %v", got, tt.wantAlreadyRevoked)
+			}
+
+			if got := server.Received("DELETE " + memberUrl); got != tt.wantDelete {
+				t.Errorf("Revoke() requests = %v, want DELETE %v", server.Requests(), tt.wantDelete)
+			}
+		})
+	}
+}
@@ -2,9 +2,11 @@ package connector
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 
+	cfg "github.com/conductorone/baton-miro/pkg/config"
 	"github.com/conductorone/baton-miro/pkg/miro"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
@@ -15,14 +17,33 @@ import (
 type Connector struct {
 	OrganizationId string
 	Client         *miro.Client
+	DefaultLicense string
+	// UseScimForTeamMembership routes team member grants and revokes through SCIM group membership.
+	UseScimForTeamMembership bool
+	// AllowLastTeamAdminRemoval allows revoking or downgrading the last admin of a team.
+	AllowLastTeamAdminRemoval bool
+	// ForceDeleteTeams allows deleting teams that still have projects or boards.
+	ForceDeleteTeams bool
+	// ForceDeleteUsers allows deleting users that are still active or own boards.
+	ForceDeleteUsers bool
+	// ForceDeleteUserGroups allows deleting user groups that are still assigned to teams.
+	ForceDeleteUserGroups bool
 }
 
 // ResourceSyncers returns a ResourceSyncer for each resource type that should be synced from the upstream service.
 func (c *Connector) ResourceSyncers(ctx context.Context) []connectorbuilder.ResourceSyncer {
+	teams := newTeamBuilder(c.Client, c.OrganizationId, c.UseScimForTeamMembership, c.ForceDeleteTeams, c.AllowLastTeamAdminRemoval)
+	roles := newRoleBuilder(c.Client, c.OrganizationId)
+
 	return []connectorbuilder.ResourceSyncer{
-		newUserBuilder(c.Client, c.OrganizationId),
-		newTeamBuilder(c.Client, c.OrganizationId),
-		newRoleBuilder(c.Client),
+		newOrganizationBuilder(c.Client, c.OrganizationId),
+		newUserBuilder(c.Client, c.OrganizationId, c.ForceDeleteUsers, teams, roles),
+		teams,
+		roles,
+		newLicenseBuilder(c.Client, c.OrganizationId, c.DefaultLicense),
+		newBoardBuilder(c.Client, c.OrganizationId),
+		newProjectBuilder(c.Client, c.OrganizationId),
+		newUserGroupBuilder(c.Client, c.OrganizationId, c.ForceDeleteUserGroups),
 	}
 }
 
@@ -36,7 +57,7 @@ func (c *Connector) Asset(ctx context.Context, asset *v2.AssetRef) (string, io.R
 func (c *Connector) Metadata(ctx context.Context) (*v2.ConnectorMetadata, error) {
 	return &v2.ConnectorMetadata{
 		DisplayName: "Miro Connector",
-		Description: "Connector syncs data from Miro, including users, teams, roles and provisioning teams, roles and users.",
+		Description: "Connector syncs data from Miro, including the organization, users, teams, roles, licenses, boards, projects, user groups and provisioning teams, roles and users.",
 		AccountCreationSchema: &v2.ConnectorAccountCreationSchema{
 			FieldMap: map[string]*v2.ConnectorAccountCreationSchema_Field{
 				"first_name": {
@@ -69,6 +90,55 @@ func (c *Connector) Metadata(ctx context.Context) (*v2.ConnectorMetadata, error)
 					Placeholder: "john.doe@example.com",
 					Order:       3,
 				},
+				"display_name": {
+					DisplayName: "Display Name",
+					Required:    false,
+					Description: "The name shown for the user in Miro.",
+					Field: &v2.ConnectorAccountCreationSchema_Field_StringField{
+						StringField: &v2.ConnectorAccountCreationSchema_StringField{},
+					},
+					Placeholder: "John Doe",
+					Order:       4,
+				},
+				"role": {
+					DisplayName: "Organization Role",
+					Required:    false,
+					Description: "The initial organization role: organization_internal_admin, organization_internal_user, organization_external_user or organization_team_guest_user.",
+					Field: &v2.ConnectorAccountCreationSchema_Field_StringField{
+						StringField: &v2.ConnectorAccountCreationSchema_StringField{},
+					},
+					Placeholder: "organization_internal_user",
+					Order:       5,
+				},
+				"license": {
+					DisplayName: "License",
+					Required:    false,
+					Description: "The license tier: full, occasional, free or free_restricted.",
+					Field: &v2.ConnectorAccountCreationSchema_Field_StringField{
+						StringField: &v2.ConnectorAccountCreationSchema_StringField{},
+					},
+					Placeholder: "full",
+					Order:       6,
+				},
+				"user_type": {
+					DisplayName: "User Type",
+					Required:    false,
+					Description: "The SCIM user type, for example Employee or Contractor.",
+					Field: &v2.ConnectorAccountCreationSchema_Field_StringField{
+						StringField: &v2.ConnectorAccountCreationSchema_StringField{},
+					},
+					Placeholder: "Employee",
+					Order:       7,
+				},
+				"teams": {
+					DisplayName: "Teams",
+					Required:    false,
+					Description: "The IDs of the teams the user joins as a member right after creation.",
+					Field: &v2.ConnectorAccountCreationSchema_Field_StringListField{
+						StringListField: &v2.ConnectorAccountCreationSchema_StringListField{},
+					},
+					Order: 8,
+				},
 			},
 		},
 	}, nil
@@ -81,15 +151,23 @@ func (c *Connector) Validate(ctx context.Context) (annotations.Annotations, erro
 }
 
 // New returns a new instance of the connector.
-func New(ctx context.Context, accessToken string, scimAccessToken string) (*Connector, error) {
-	httpClient, err := uhttp.NewBearerAuth(accessToken).GetClient(ctx)
+func New(ctx context.Context, config *cfg.Miro) (*Connector, error) {
+	defaultLicense := config.DefaultLicense
+	if defaultLicense == "" {
+		defaultLicense = freeLicense
+	}
+	if _, ok := licenseDefinitions[defaultLicense]; !ok {
+		return nil, fmt.Errorf("baton-miro: invalid default license %s", defaultLicense)
+	}
+
+	httpClient, err := uhttp.NewBearerAuth(config.AccessToken).GetClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	var scimClient *http.Client
-	if scimAccessToken != "" {
-		scimClient, err = uhttp.NewBearerAuth(scimAccessToken).GetClient(ctx)
+	if config.ScimAccessToken != "" {
+		scimClient, err = uhttp.NewBearerAuth(config.ScimAccessToken).GetClient(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -105,5 +183,12 @@ func New(ctx context.Context, accessToken string, scimAccessToken string) (*Conn
 	return &Connector{
 		Client:         client,
 		OrganizationId: context.Organization.Id,
+		DefaultLicense: defaultLicense,
+		// Team membership can only go through SCIM when a SCIM token is configured.
+		UseScimForTeamMembership:  config.UseScimForTeamMembership && scimClient != nil,
+		AllowLastTeamAdminRemoval: config.AllowLastTeamAdminRemoval,
+		ForceDeleteTeams:          config.ForceDeleteTeams,
+		ForceDeleteUsers:          config.ForceDeleteUsers,
+		ForceDeleteUserGroups:     config.ForceDeleteUserGroups,
 	}, nil
 }
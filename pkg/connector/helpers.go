@@ -2,10 +2,14 @@ package connector
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -38,10 +42,54 @@ func handleNextPage(bag *pagination.Bag, nextCursor string) (string, error) {
 	return pageToken, nil
 }
 
+// parseOffset converts an offset page token into the offset expected by the Miro API.
+func parseOffset(token string) (int32, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	offset, err := strconv.ParseInt(token, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid offset page token: %s", token)
+	}
+
+	return int32(offset), nil
+}
+
+// nextOffset returns the offset of the next page, or an empty string when there are no more pages.
+func nextOffset(offset int32, size int32, total int32) string {
+	next := offset + size
+	if size == 0 || next >= total {
+		return ""
+	}
+
+	return strconv.Itoa(int(next))
+}
+
 func wrapError(err error, message string) error {
 	return fmt.Errorf("miro-connector: %s: %w", message, err)
 }
 
+// isNotFoundError reports whether the Miro API answered with 404 Not Found.
+func isNotFoundError(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
+
+// isPermissionDeniedError reports whether the Miro API answered with 403 Forbidden.
+func isPermissionDeniedError(err error) bool {
+	return status.Code(err) == codes.PermissionDenied
+}
+
+// parseRoleFromEntitlementID returns the role slug of an entitlement ID in the form resource_type:resource_id:role.
+func parseRoleFromEntitlementID(entitlementID string) (string, error) {
+	parts := strings.Split(entitlementID, ":")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("invalid entitlement ID: %s", entitlementID)
+	}
+
+	return parts[2], nil
+}
+
 func contains(s []string, str string) bool {
 	for _, v := range s {
 		if v == str {
@@ -0,0 +1,163 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conductorone/baton-miro/pkg/miro"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	"github.com/conductorone/baton-sdk/pkg/types/grant"
+	"github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+const (
+	freeLicense = "free"
+)
+
+// licenseDefinition is the definition of a license tier.
+type licenseDefinition struct {
+	ID          string
+	DisplayName string
+	Description string
+	ScimKey     string
+}
+
+// licenseDefinitions is the map of license definitions keyed by ID.
+var licenseDefinitions = map[string]licenseDefinition{
+	"full":            {ID: "full", DisplayName: "Full License", Description: "Paid Miro seat with full access", ScimKey: "FULL"},
+	"occasional":      {ID: "occasional", DisplayName: "Occasional License", Description: "Miro seat billed for occasional use", ScimKey: "OCCASIONAL"},
+	"free":            {ID: "free", DisplayName: "Free License", Description: "Free Miro seat", ScimKey: "FREE"},
+	"free_restricted": {ID: "free_restricted", DisplayName: "Free Restricted License", Description: "Free Miro seat with restricted access", ScimKey: "FREE_RESTRICTED"},
+}
+
+// licenseBuilder is the builder for the license resource type.
+type licenseBuilder struct {
+	client         *miro.Client
+	resourceType   *v2.ResourceType
+	organizationId string
+	defaultLicense string
+}
+
+// ResourceType returns the resource type for the license builder.
+func (l *licenseBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return l.resourceType
+}
+
+// List returns a resource for each Miro license tier.
+func (l *licenseBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	var resources []*v2.Resource
+	for _, license := range licenseDefinitions {
+		licenseResource, err := resource.NewResource(
+			license.DisplayName,
+			l.resourceType,
+			license.ID,
+			resource.WithDescription(license.Description),
+		)
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("failed to create license resource: %w", err)
+		}
+		resources = append(resources, licenseResource)
+	}
+	return resources, "", nil, nil
+}
+
+// Entitlements returns the entitlements for the license builder.
+func (l *licenseBuilder) Entitlements(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	var rv []*v2.Entitlement
+
+	assigmentOptions := []entitlement.EntitlementOption{
+		entitlement.WithGrantableTo(userResourceType),
+		entitlement.WithDescription(fmt.Sprintf("Has Miro %s", resource.DisplayName)),
+		entitlement.WithDisplayName(fmt.Sprintf("%s %s", resource.DisplayName, assignedRole)),
+	}
+
+	entitlement := entitlement.NewAssignmentEntitlement(resource, assignedRole, assigmentOptions...)
+	rv = append(rv, entitlement)
+
+	return rv, "", nil, nil
+}
+
+// Grants returns empty grants - license grants are emitted from user resources.
+func (l *licenseBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
+
+// Grant assigns a license tier to a user.
+func (l *licenseBuilder) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
+	if principal.Id.ResourceType != userResourceType.Id {
+		return nil, nil, fmt.Errorf("baton-miro: only users can be granted a license")
+	}
+
+	userID := principal.Id.Resource
+	licenseID := entitlement.Resource.Id.Resource
+
+	licenseDefinition, ok := licenseDefinitions[licenseID]
+	if !ok {
+		return nil, nil, fmt.Errorf("license not found for ID: %s", licenseID)
+	}
+
+	user, annos, err := l.client.GetOrganizationMember(ctx, l.organizationId, userID)
+	if err != nil {
+		return nil, annos, fmt.Errorf("failed to get user %s: %w", userID, err)
+	}
+
+	if user.License == licenseDefinition.ID {
+		return nil, annotations.New(&v2.GrantAlreadyExists{}), nil
+	}
+
+	_, annos, err = l.client.UpdateUserLicense(ctx, userID, licenseDefinition.ScimKey)
+	if err != nil {
+		return nil, annos, fmt.Errorf("failed to update license for user %s: %w", userID, err)
+	}
+
+	g := grant.NewGrant(entitlement.Resource, assignedRole, principal.Id)
+	return []*v2.Grant{g}, annos, nil
+}
+
+// Revoke moves a user from the revoked license tier to the default license.
+func (l *licenseBuilder) Revoke(ctx context.Context, g *v2.Grant) (annotations.Annotations, error) {
+	userID := g.Principal.Id.Resource
+	licenseID := g.Entitlement.Resource.Id.Resource
+
+	if _, ok := licenseDefinitions[licenseID]; !ok {
+		return nil, fmt.Errorf("license not found for ID: %s", licenseID)
+	}
+
+	defaultLicense, ok := licenseDefinitions[l.defaultLicense]
+	if !ok {
+		return nil, fmt.Errorf("default license not found for ID: %s", l.defaultLicense)
+	}
+
+	user, annos, err := l.client.GetOrganizationMember(ctx, l.organizationId, userID)
+	if err != nil {
+		return annos, fmt.Errorf("failed to get user %s: %w", userID, err)
+	}
+
+	if user.License != licenseID {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+
+	if licenseID == defaultLicense.ID {
+		return annos, fmt.Errorf("baton-miro: the default license %s cannot be revoked, grant another license instead", defaultLicense.ID)
+	}
+
+	_, annos, err = l.client.UpdateUserLicense(ctx, userID, defaultLicense.ScimKey)
+	if err != nil {
+		return annos, fmt.Errorf("failed to set default license for user %s: %w", userID, err)
+	}
+
+	return annos, nil
+}
+
+// newLicenseBuilder creates a new license builder.
+func newLicenseBuilder(client *miro.Client, organizationId string, defaultLicense string) *licenseBuilder {
+	return &licenseBuilder{
+		client:         client,
+		resourceType:   licenseResourceType,
+		organizationId: organizationId,
+		defaultLicense: defaultLicense,
+	}
+}
@@ -0,0 +1,176 @@
+package connector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/conductorone/baton-miro/pkg/miro"
+	"github.com/conductorone/baton-miro/test"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+)
+
+// TestLicenseBuilder_List tests the list method for the license builder.
+func TestLicenseBuilder_List(t *testing.T) {
+	builder := &licenseBuilder{
+		resourceType: licenseResourceType,
+	}
+
+	resources, nextPage, _, err := builder.List(context.Background(), &v2.ResourceId{}, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if nextPage != "" {
+		t.Errorf("List() nextPage = %v, want empty string", nextPage)
+	}
+
+	if len(resources) != len(licenseDefinitions) {
+		t.Errorf("List() count = %v, want %v", len(resources), len(licenseDefinitions))
+	}
+
+	for i, resource := range resources {
+		expected, exists := licenseDefinitions[resource.Id.Resource]
+		if !exists {
+			t.Errorf("List()[%d].Id.Resource = %v, license definition not found", i, resource.Id.Resource)
+			continue
+		}
+		if resource.DisplayName != expected.DisplayName {
+			t.Errorf("List()[%d].DisplayName = %v, want %v", i, resource.DisplayName, expected.DisplayName)
+		}
+	}
+}
+
+// TestLicenseBuilder_Entitlements tests the entitlements for a license.
+func TestLicenseBuilder_Entitlements(t *testing.T) {
+	builder := &licenseBuilder{
+		resourceType: licenseResourceType,
+	}
+
+	resource := &v2.Resource{
+		Id: &v2.ResourceId{
+			ResourceType: licenseResourceType.Id,
+			Resource:     "full",
+		},
+		DisplayName: "Full License",
+	}
+
+	entitlements, _, _, err := builder.Entitlements(context.Background(), resource, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("Entitlements() error = %v", err)
+	}
+
+	if len(entitlements) != 1 {
+		t.Fatalf("Entitlements() count = %v, want 1", len(entitlements))
+	}
+
+	if entitlements[0].Slug != assignedRole {
+		t.Errorf("Entitlements()[0].Slug = %v, want %v", entitlements[0].Slug, assignedRole)
+	}
+}
+
+// TestUserLicenseGrant tests the license grant emitted for a user.
+func TestUserLicenseGrant(t *testing.T) {
+	builder := &userBuilder{
+		resourceType: userResourceType,
+	}
+
+	resource := &v2.Resource{
+		Id: &v2.ResourceId{
+			ResourceType: userResourceType.Id,
+			Resource:     mockUserID,
+		},
+	}
+
+	g := builder.licenseGrant(&miro.User{Id: mockUserID, License: "occasional"}, resource)
+	if g == nil {
+		t.Fatal("licenseGrant() returned nil for occasional license")
+	}
+
+	if g.Entitlement.Resource.Id.Resource != "occasional" {
+		t.Errorf("licenseGrant() license = %v, want occasional", g.Entitlement.Resource.Id.Resource)
+	}
+
+	if g.Principal.Id.Resource != mockUserID {
+		t.Errorf("licenseGrant() principal = %v, want %v", g.Principal.Id.Resource, mockUserID)
+	}
+
+	if builder.licenseGrant(&miro.User{Id: mockUserID, License: "unknown"}, resource) != nil {
+		t.Error("licenseGrant() expected nil for unknown license")
+	}
+}
+
+// TestLicenseDefinitionsConsistency tests that every license has a SCIM key.
+func TestLicenseDefinitionsConsistency(t *testing.T) {
+	expectedScimKeys := map[string]string{
+		"full":            "FULL",
+		"occasional":      "OCCASIONAL",
+		"free":            "FREE",
+		"free_restricted": "FREE_RESTRICTED",
+	}
+
+	if len(licenseDefinitions) != len(expectedScimKeys) {
+		t.Errorf("licenseDefinitions length = %v, want %v", len(licenseDefinitions), len(expectedScimKeys))
+	}
+
+	for id, definition := range licenseDefinitions {
+		if definition.ID != id {
+			t.Errorf("licenseDefinitions[%s].ID = %v, want %v", id, definition.ID, id)
+		}
+		if definition.ScimKey != expectedScimKeys[id] {
+			t.Errorf("licenseDefinitions[%s].ScimKey = %v, want %v", id, definition.ScimKey, expectedScimKeys[id])
+		}
+	}
+
+	if _, ok := licenseDefinitions[freeLicense]; !ok {
+		t.Errorf("default license %s is not a license definition", freeLicense)
+	}
+}
+
+// TestLicenseBuilder_Revoke tests revoking licenses against a mock Miro API.
+func TestLicenseBuilder_Revoke(t *testing.T) {
+	userUrl := "/api/v1/scim/Users/" + testUserID
+
+	tests := []struct {
+		name           string
+		defaultLicense string
+		license        string
+		wantErr        bool
+		wantRevoked    bool
+		wantPatch      bool
+	}{
+		{name: "license held", defaultLicense: freeLicense, license: "full", wantPatch: true},
+		{name: "license not held", defaultLicense: freeLicense, license: "occasional", wantRevoked: true},
+		{name: "default license held", defaultLicense: "full", license: "full", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := test.NewMockServerClient(t, map[string]test.MockResponse{
+				"GET /v2/orgs/" + test.MockOrgID + "/members/" + testUserID: {File: "organization_user_success.json"},
+				"PATCH " + userUrl: {File: "scim_user_success.json"},
+			})
+			builder := newLicenseBuilder(client, test.MockOrgID, tt.defaultLicense)
+
+			g := &v2.Grant{
+				Entitlement: &v2.Entitlement{
+					Resource: &v2.Resource{Id: &v2.ResourceId{ResourceType: licenseResourceType.Id, Resource: tt.license}},
+				},
+				Principal: &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: testUserID}},
+			}
+
+			annos, err := builder.Revoke(context.Background(), g)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Revoke() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := annos.Contains(&v2.GrantAlreadyRevoked{}); got != tt.wantRevoked {
+				t.Errorf("Revoke() GrantAlreadyRevoked = %v, want %v", got, tt.wantRevoked)
+			}
+
+			if got := server.Received("PATCH " + userUrl); got != tt.wantPatch {
+				t.Errorf("Revoke() requests = %v, want license update %v", server.Requests(), tt.wantPatch)
+			}
+		})
+	}
+}
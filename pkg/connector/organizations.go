@@ -0,0 +1,137 @@
+package connector
+
+import (
+	"context"
+
+	"github.com/conductorone/baton-miro/pkg/miro"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+type organizationBuilder struct {
+	resourceType   *v2.ResourceType
+	client         *miro.Client
+	organizationId string
+}
+
+// licenseUsage is the number of organization members holding each paid license tier.
+type licenseUsage struct {
+	full       int32
+	occasional int32
+}
+
+// ResourceType returns the resource type for the organization builder.
+func (o *organizationBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return organizationResourceType
+}
+
+// organizationResource creates the organization resource. Miro only reports how many full licenses were
+// purchased; the API has no purchased figure for occasional licenses, so only their usage is included.
+func organizationResource(organization *miro.Organization, usage *licenseUsage) (*v2.Resource, error) {
+	profile := map[string]interface{}{
+		"name":                     organization.Name,
+		"id":                       organization.Id,
+		"plan":                     organization.Plan,
+		"full_licenses_purchased":  organization.FullLicensesPurchased,
+		"full_licenses_used":       usage.full,
+		"occasional_licenses_used": usage.occasional,
+	}
+
+	organizationTraitOptions := []rs.GroupTraitOption{
+		rs.WithGroupProfile(profile),
+	}
+	resource, err := rs.NewGroupResource(
+		organization.Name,
+		organizationResourceType,
+		organization.Id,
+		organizationTraitOptions,
+		rs.WithAnnotation(
+			&v2.ChildResourceType{ResourceTypeId: userResourceType.Id},
+			&v2.ChildResourceType{ResourceTypeId: teamResourceType.Id},
+			&v2.ChildResourceType{ResourceTypeId: userGroupResourceType.Id},
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// organizationResourceId returns the resource ID of the organization with the given ID.
+func organizationResourceId(organizationId string) *v2.ResourceId {
+	return &v2.ResourceId{
+		ResourceType: organizationResourceType.Id,
+		Resource:     organizationId,
+	}
+}
+
+// newOrganizationBuilder creates a new organization builder.
+func newOrganizationBuilder(client *miro.Client, organizationId string) *organizationBuilder {
+	return &organizationBuilder{
+		resourceType:   organizationResourceType,
+		client:         client,
+		organizationId: organizationId,
+	}
+}
+
+// countLicenseUsage pages through the organization members to count full and occasional licenses.
+func (o *organizationBuilder) countLicenseUsage(ctx context.Context) (*licenseUsage, annotations.Annotations, error) {
+	usage := &licenseUsage{}
+
+	var annos annotations.Annotations
+	cursor := ""
+	for {
+		response, respAnnos, err := o.client.GetOrganizationMembers(ctx, o.organizationId, cursor, resourcePageSize)
+		if err != nil {
+			return nil, respAnnos, err
+		}
+		annos = respAnnos
+
+		for _, user := range response.Data {
+			switch user.License {
+			case "full":
+				usage.full++
+			case "occasional":
+				usage.occasional++
+			}
+		}
+
+		if response.Cursor == "" {
+			return usage, annos, nil
+		}
+		cursor = response.Cursor
+	}
+}
+
+// List returns the organization the connector is configured for.
+func (o *organizationBuilder) List(ctx context.Context, _ *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	organization, annos, err := o.client.GetOrganization(ctx, o.organizationId)
+	if err != nil {
+		return nil, "", annos, wrapError(err, "failed to get organization")
+	}
+
+	usage, annos, err := o.countLicenseUsage(ctx)
+	if err != nil {
+		return nil, "", annos, wrapError(err, "failed to count organization licenses")
+	}
+
+	resource, err := organizationResource(organization, usage)
+	if err != nil {
+		return nil, "", annos, wrapError(err, "failed to create organization resource")
+	}
+
+	return []*v2.Resource{resource}, "", annos, nil
+}
+
+// Entitlements always returns an empty slice for the organization.
+func (o *organizationBuilder) Entitlements(_ context.Context, _ *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
+
+// Grants always returns an empty slice for the organization.
+func (o *organizationBuilder) Grants(_ context.Context, _ *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
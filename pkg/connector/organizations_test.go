@@ -0,0 +1,124 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/conductorone/baton-miro/pkg/miro"
+	"github.com/conductorone/baton-miro/test"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+// TestOrganizationMockData tests the organization mock data.
+func TestOrganizationMockData(t *testing.T) {
+	mockData := test.ReadFile("organization_success.json")
+
+	var organization miro.Organization
+	err := json.Unmarshal([]byte(mockData), &organization)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal mock organization data: %v", err)
+	}
+
+	if organization.Id != test.MockOrgID {
+		t.Errorf("Expected organization ID to be %s, got %s", test.MockOrgID, organization.Id)
+	}
+
+	if organization.Plan != "enterprise" {
+		t.Errorf("Expected plan to be 'enterprise', got %s", organization.Plan)
+	}
+
+	if organization.FullLicensesPurchased != 250 {
+		t.Errorf("Expected 250 full licenses purchased, got %d", organization.FullLicensesPurchased)
+	}
+}
+
+// TestOrganizationResource tests the organization resource.
+func TestOrganizationResource(t *testing.T) {
+	organization := &miro.Organization{
+		Id:                    test.MockOrgID,
+		Name:                  "Example Corp",
+		Plan:                  "enterprise",
+		FullLicensesPurchased: 250,
+	}
+
+	resource, err := organizationResource(organization, &licenseUsage{full: 200, occasional: 12})
+	if err != nil {
+		t.Fatalf("organizationResource() error = %v", err)
+	}
+
+	if resource.Id.ResourceType != organizationResourceType.Id {
+		t.Errorf("organizationResource() Id.ResourceType = %v, want %v", resource.Id.ResourceType, organizationResourceType.Id)
+	}
+
+	groupTrait, err := rs.GetGroupTrait(resource)
+	if err != nil {
+		t.Fatalf("GetGroupTrait() error = %v", err)
+	}
+
+	plan, ok := rs.GetProfileStringValue(groupTrait.Profile, "plan")
+	if !ok || plan != "enterprise" {
+		t.Errorf("organizationResource() profile plan = %v, want enterprise", plan)
+	}
+
+	used, ok := rs.GetProfileInt64Value(groupTrait.Profile, "full_licenses_used")
+	if !ok || used != 200 {
+		t.Errorf("organizationResource() profile full_licenses_used = %v, want 200", used)
+	}
+
+	childTypes := map[string]bool{}
+	for _, a := range resource.Annotations {
+		child := &v2.ChildResourceType{}
+		if a.MessageIs(child) {
+			if err := a.UnmarshalTo(child); err != nil {
+				t.Fatalf("UnmarshalTo() error = %v", err)
+			}
+			childTypes[child.ResourceTypeId] = true
+		}
+	}
+
+	if !childTypes[userResourceType.Id] || !childTypes[teamResourceType.Id] {
+		t.Errorf("organizationResource() child resource types = %v, want user and team", childTypes)
+	}
+}
+
+// TestOrganizationBuilder_ListCountsLicenseUsage tests that each organization listing counts licenses once.
+func TestOrganizationBuilder_ListCountsLicenseUsage(t *testing.T) {
+	orgUrl := "/v2/orgs/" + test.MockOrgID
+	membersKey := "GET " + orgUrl + "/members"
+	client, server := test.NewMockServerClient(t, map[string]test.MockResponse{
+		"GET " + orgUrl: {File: "organization_success.json"},
+		membersKey:      {File: "organization_members_success.json"},
+	})
+	builder := newOrganizationBuilder(client, test.MockOrgID)
+
+	for i := 0; i < 2; i++ {
+		resources, _, _, err := builder.List(context.Background(), nil, &pagination.Token{})
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+
+		groupTrait, err := rs.GetGroupTrait(resources[0])
+		if err != nil {
+			t.Fatalf("GetGroupTrait() error = %v", err)
+		}
+
+		fullUsed, _ := rs.GetProfileInt64Value(groupTrait.Profile, "full_licenses_used")
+		occasionalUsed, _ := rs.GetProfileInt64Value(groupTrait.Profile, "occasional_licenses_used")
+		if fullUsed != 1 || occasionalUsed != 1 {
+			t.Errorf("List() licenses used = %d full, %d occasional, want 1 and 1", fullUsed, occasionalUsed)
+		}
+	}
+
+	memberRequests := 0
+	for _, request := range server.Requests() {
+		if request == membersKey {
+			memberRequests++
+		}
+	}
+	if memberRequests != 2 {
+		t.Errorf("List() fetched organization members %d times, want 2", memberRequests)
+	}
+}
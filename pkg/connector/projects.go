@@ -0,0 +1,321 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conductorone/baton-miro/pkg/miro"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+type projectBuilder struct {
+	resourceType   *v2.ResourceType
+	client         *miro.Client
+	organizationId string
+}
+
+const (
+	ownerProjectRole     = "owner"
+	editorProjectRole    = "editor"
+	commenterProjectRole = "commenter"
+	viewerProjectRole    = "viewer"
+)
+
+var projectRoles = []string{
+	ownerProjectRole,
+	editorProjectRole,
+	commenterProjectRole,
+	viewerProjectRole,
+}
+
+// ResourceType returns the resource type for the project builder.
+func (o *projectBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return projectResourceType
+}
+
+func projectResource(project *miro.Project, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	profile := map[string]interface{}{
+		"name":    project.Name,
+		"id":      project.Id,
+		"team_id": parentResourceID.Resource,
+	}
+
+	projectTraitOptions := []rs.GroupTraitOption{
+		rs.WithGroupProfile(profile),
+	}
+	resource, err := rs.NewGroupResource(
+		project.Name,
+		projectResourceType,
+		project.Id,
+		projectTraitOptions,
+		rs.WithParentResourceID(parentResourceID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// projectTeamId returns the ID of the team a project resource belongs to.
+func projectTeamId(resource *v2.Resource) (string, error) {
+	if resource.ParentResourceId != nil && resource.ParentResourceId.Resource != "" {
+		return resource.ParentResourceId.Resource, nil
+	}
+
+	groupTrait, err := rs.GetGroupTrait(resource)
+	if err == nil {
+		if teamId, ok := rs.GetProfileStringValue(groupTrait.Profile, "team_id"); ok && teamId != "" {
+			return teamId, nil
+		}
+	}
+
+	return "", fmt.Errorf("baton-miro: project %s has no parent team", resource.Id.Resource)
+}
+
+// newProjectBuilder creates a new project builder.
+func newProjectBuilder(client *miro.Client, organizationId string) *projectBuilder {
+	return &projectBuilder{
+		resourceType:   projectResourceType,
+		client:         client,
+		organizationId: organizationId,
+	}
+}
+
+// List returns the projects of a team.
+func (o *projectBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	if parentResourceID == nil {
+		return nil, "", nil, nil
+	}
+
+	bag, cursor, err := parsePageToken(pToken.Token, &v2.ResourceId{ResourceType: o.resourceType.Id})
+	if err != nil {
+		return nil, "", nil, wrapError(err, "failed to parse page token")
+	}
+
+	response, annos, err := o.client.GetProjects(ctx, o.organizationId, parentResourceID.Resource, cursor, resourcePageSize)
+	if err != nil {
+		// The projects endpoint returns 404 when projects are unavailable for the team.
+		if isNotFoundError(err) {
+			return nil, "", annos, nil
+		}
+		return nil, "", annos, wrapError(err, "failed to get projects")
+	}
+
+	var resources []*v2.Resource
+	for _, project := range response.Data {
+		project := project
+		resource, err := projectResource(&project, parentResourceID)
+		if err != nil {
+			return nil, "", annos, wrapError(err, "failed to create project resource")
+		}
+
+		resources = append(resources, resource)
+	}
+
+	if response.Cursor == "" {
+		return resources, "", annos, nil
+	}
+
+	nextCursor, err := handleNextPage(bag, response.Cursor)
+	if err != nil {
+		return nil, "", annos, wrapError(err, "failed to create next page cursor")
+	}
+
+	return resources, nextCursor, annos, nil
+}
+
+// Entitlements returns the project roles as entitlements.
+func (o *projectBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	var rv []*v2.Entitlement
+
+	for _, role := range projectRoles {
+		assigmentOptions := []ent.EntitlementOption{
+			ent.WithDescription(fmt.Sprintf("Has %s role on %s project", role, resource.DisplayName)),
+			ent.WithDisplayName(fmt.Sprintf("%s project role %s", resource.DisplayName, role)),
+		}
+		// Project ownership cannot be granted through the API, so the owner role is sync-only.
+		if role != ownerProjectRole {
+			assigmentOptions = append(assigmentOptions, ent.WithGrantableTo(userResourceType))
+		}
+
+		entitlement := ent.NewAssignmentEntitlement(resource, role, assigmentOptions...)
+		rv = append(rv, entitlement)
+	}
+
+	return rv, "", nil, nil
+}
+
+// Grants returns a grant for each member of a project.
+func (o *projectBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	teamId, err := projectTeamId(resource)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	bag, cursor, err := parsePageToken(pToken.Token, &v2.ResourceId{ResourceType: o.resourceType.Id})
+	if err != nil {
+		return nil, "", nil, wrapError(err, "failed to parse page token")
+	}
+
+	response, annos, err := o.client.GetProjectMembers(ctx, o.organizationId, teamId, resource.Id.Resource, cursor, resourcePageSize)
+	if err != nil {
+		return nil, "", annos, wrapError(err, "failed to get project members")
+	}
+
+	var grants []*v2.Grant
+	for _, member := range response.Data {
+		if !contains(projectRoles, member.Role) {
+			continue
+		}
+
+		userResourceId := &v2.ResourceId{
+			ResourceType: userResourceType.Id,
+			Resource:     member.Id,
+		}
+
+		g := grant.NewGrant(resource, member.Role, userResourceId)
+		grants = append(grants, g)
+	}
+
+	if response.Cursor == "" {
+		return grants, "", annos, nil
+	}
+
+	nextCursor, err := handleNextPage(bag, response.Cursor)
+	if err != nil {
+		return nil, "", annos, wrapError(err, "failed to create next page cursor")
+	}
+
+	return grants, nextCursor, annos, nil
+}
+
+// Grant adds a user to a project, or changes the role of an existing project member in place.
+func (o *projectBuilder) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	if principal.Id.ResourceType != userResourceType.Id {
+		err := fmt.Errorf("baton-miro: only users can be granted project roles")
+
+		l.Warn(
+			err.Error(),
+			zap.String("principal_id", principal.Id.Resource),
+			zap.String("principal_type", principal.Id.ResourceType),
+		)
+
+		return nil, nil, err
+	}
+
+	role, err := parseRoleFromEntitlementID(entitlement.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !contains(projectRoles, role) {
+		return nil, nil, fmt.Errorf("baton-miro: invalid project role %s", role)
+	}
+	if role == ownerProjectRole {
+		return nil, nil, fmt.Errorf("baton-miro: project ownership cannot be granted")
+	}
+
+	teamId, err := projectTeamId(entitlement.Resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	projectId := entitlement.Resource.Id.Resource
+	userId := principal.Id.Resource
+
+	member, annos, err := o.client.GetProjectMember(ctx, o.organizationId, teamId, projectId, userId)
+	if err != nil && !isNotFoundError(err) {
+		return nil, annos, wrapError(err, "failed to get project member")
+	}
+
+	switch {
+	case member == nil:
+		var user *miro.User
+		user, annos, err = o.client.GetOrganizationMember(ctx, o.organizationId, userId)
+		if err != nil {
+			return nil, annos, wrapError(err, "failed to get user")
+		}
+
+		_, annos, err = o.client.AddProjectMember(ctx, o.organizationId, teamId, projectId, user.Email, role)
+		if err != nil {
+			return nil, annos, wrapError(err, "failed to add user to project")
+		}
+	case member.Role == role:
+		return nil, annotations.New(&v2.GrantAlreadyExists{}), nil
+	case member.Role == ownerProjectRole:
+		return nil, annos, fmt.Errorf("baton-miro: the role of the project owner cannot be changed")
+	default:
+		_, annos, err = o.client.UpdateProjectMember(ctx, o.organizationId, teamId, projectId, userId, role)
+		if err != nil {
+			return nil, annos, wrapError(err, "failed to update project member role")
+		}
+	}
+
+	g := grant.NewGrant(entitlement.Resource, role, principal.Id)
+	return []*v2.Grant{g}, annos, nil
+}
+
+// Revoke removes a user from a project when they still hold the revoked role.
+func (o *projectBuilder) Revoke(ctx context.Context, g *v2.Grant) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	entitlement := g.Entitlement
+	principal := g.Principal
+
+	if principal.Id.ResourceType != userResourceType.Id {
+		err := fmt.Errorf("baton-miro: only users can be revoked from project")
+
+		l.Warn(
+			err.Error(),
+			zap.String("principal_id", principal.Id.Resource),
+			zap.String("principal_type", principal.Id.ResourceType),
+		)
+
+		return nil, err
+	}
+
+	role, err := parseRoleFromEntitlementID(entitlement.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	teamId, err := projectTeamId(entitlement.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	projectId := entitlement.Resource.Id.Resource
+	userId := principal.Id.Resource
+
+	member, annos, err := o.client.GetProjectMember(ctx, o.organizationId, teamId, projectId, userId)
+	if err != nil {
+		if isNotFoundError(err) {
+			return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+		}
+		return annos, wrapError(err, "failed to get project member")
+	}
+
+	if member.Role != role {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+
+	if role == ownerProjectRole {
+		return nil, fmt.Errorf("baton-miro: the project owner cannot be removed from the project")
+	}
+
+	annos, err = o.client.RemoveProjectMember(ctx, o.organizationId, teamId, projectId, userId)
+	if err != nil {
+		return annos, wrapError(err, "failed to remove user from project")
+	}
+
+	return annos, nil
+}
@@ -0,0 +1,322 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/conductorone/baton-miro/pkg/miro"
+	"github.com/conductorone/baton-miro/test"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+)
+
+const (
+	testProjectID = "project-123"
+)
+
+// TestProjectResource tests the project resource.
+func TestProjectResource(t *testing.T) {
+	project := &miro.Project{
+		Id:   testProjectID,
+		Name: "Platform",
+		Type: "project",
+	}
+	parent := &v2.ResourceId{ResourceType: teamResourceType.Id, Resource: testTeamID}
+
+	resource, err := projectResource(project, parent)
+	if err != nil {
+		t.Fatalf("projectResource() error = %v", err)
+	}
+
+	if resource.DisplayName != "Platform" {
+		t.Errorf("projectResource() DisplayName = %v, want %v", resource.DisplayName, "Platform")
+	}
+
+	if resource.Id.ResourceType != projectResourceType.Id {
+		t.Errorf("projectResource() Id.ResourceType = %v, want %v", resource.Id.ResourceType, projectResourceType.Id)
+	}
+
+	teamId, err := projectTeamId(resource)
+	if err != nil {
+		t.Fatalf("projectTeamId() error = %v", err)
+	}
+
+	if teamId != testTeamID {
+		t.Errorf("projectTeamId() = %v, want %v", teamId, testTeamID)
+	}
+}
+
+// TestProjectTeamIdWithoutParent tests that a project without a parent team is rejected.
+func TestProjectTeamIdWithoutParent(t *testing.T) {
+	resource := &v2.Resource{
+		Id: &v2.ResourceId{
+			ResourceType: projectResourceType.Id,
+			Resource:     testProjectID,
+		},
+	}
+
+	if _, err := projectTeamId(resource); err == nil {
+		t.Error("projectTeamId() expected error for project without parent team")
+	}
+}
+
+// TestProjectMockData tests the project mock data.
+func TestProjectMockData(t *testing.T) {
+	mockData := test.ReadFile("projects_success.json")
+
+	var response miro.GetProjectsResponse
+	err := json.Unmarshal([]byte(mockData), &response)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal mock projects data: %v", err)
+	}
+
+	if len(response.Data) != 1 {
+		t.Errorf("Expected 1 project in mock data, got %d", len(response.Data))
+	}
+
+	if response.Data[0].Id != testProjectID {
+		t.Errorf("Expected first project ID to be %s, got %s", testProjectID, response.Data[0].Id)
+	}
+}
+
+// TestProjectMembersMockData tests the project members mock data.
+func TestProjectMembersMockData(t *testing.T) {
+	mockData := test.ReadFile("project_members_success.json")
+
+	var response miro.GetProjectMembersResponse
+	err := json.Unmarshal([]byte(mockData), &response)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal mock project members data: %v", err)
+	}
+
+	if len(response.Data) != 2 {
+		t.Errorf("Expected 2 members in mock data, got %d", len(response.Data))
+	}
+
+	if response.Data[0].Role != ownerProjectRole {
+		t.Errorf("Expected first member role to be '%s', got %s", ownerProjectRole, response.Data[0].Role)
+	}
+}
+
+// TestProjectBuilder_Entitlements tests the entitlements for a project.
+func TestProjectBuilder_Entitlements(t *testing.T) {
+	builder := &projectBuilder{
+		resourceType: projectResourceType,
+	}
+
+	resource := &v2.Resource{
+		Id: &v2.ResourceId{
+			ResourceType: projectResourceType.Id,
+			Resource:     testProjectID,
+		},
+		DisplayName: "Platform",
+	}
+
+	entitlements, _, _, err := builder.Entitlements(context.Background(), resource, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("Entitlements() error = %v", err)
+	}
+
+	if len(entitlements) != len(projectRoles) {
+		t.Errorf("Entitlements() count = %v, want %v", len(entitlements), len(projectRoles))
+	}
+
+	for i, role := range projectRoles {
+		grantable := len(entitlements[i].GrantableTo) > 0
+		if grantable != (role != ownerProjectRole) {
+			t.Errorf("Entitlements()[%d] grantable = %v for role %v", i, grantable, role)
+		}
+	}
+}
+
+// TestProjectTeamIdFromProfile tests that the team is read from the profile when the parent is missing.
+func TestProjectTeamIdFromProfile(t *testing.T) {
+	project := &miro.Project{
+		Id:   testProjectID,
+		Name: "Platform",
+	}
+	parent := &v2.ResourceId{ResourceType: teamResourceType.Id, Resource: testTeamID}
+
+	resource, err := projectResource(project, parent)
+	if err != nil {
+		t.Fatalf("projectResource() error = %v", err)
+	}
+	resource.ParentResourceId = nil
+
+	teamId, err := projectTeamId(resource)
+	if err != nil {
+		t.Fatalf("projectTeamId() error = %v", err)
+	}
+
+	if teamId != testTeamID {
+		t.Errorf("projectTeamId() = %v, want %v", teamId, testTeamID)
+	}
+}
+
+// TestProjectBuilder_OwnerGuards tests that the project owner role cannot be granted, changed or revoked.
+func TestProjectBuilder_OwnerGuards(t *testing.T) {
+	memberUrl := "/v2/orgs/" + test.MockOrgID + "/teams/" + testTeamID + "/projects/" + testProjectID + "/members/" + testUserID
+	routes := map[string]test.MockResponse{
+		"GET " + memberUrl: {File: "project_member_owner_success.json"},
+	}
+
+	project, err := projectResource(&miro.Project{Id: testProjectID, Name: "Platform"}, &v2.ResourceId{ResourceType: teamResourceType.Id, Resource: testTeamID})
+	if err != nil {
+		t.Fatalf("projectResource() error = %v", err)
+	}
+	principal := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: testUserID}}
+	entitlement := func(role string) *v2.Entitlement {
+		return &v2.Entitlement{Id: "project:" + testProjectID + ":" + role, Resource: project}
+	}
+
+	client, server := test.NewMockServerClient(t, routes)
+	builder := newProjectBuilder(client, test.MockOrgID)
+
+	if _, _, err := builder.Grant(context.Background(), principal, entitlement(ownerProjectRole)); err == nil {
+		t.Error("Grant() of the owner role should fail")
+	}
+
+	if _, _, err := builder.Grant(context.Background(), principal, entitlement(editorProjectRole)); err == nil {
+		t.Error("Grant() changing the owner's role should fail")
+	}
+
+	if _, err := builder.Revoke(context.Background(), &v2.Grant{Entitlement: entitlement(ownerProjectRole), Principal: principal}); err == nil {
+		t.Error("Revoke() of the project owner should fail")
+	}
+
+	if !server.Received("GET " + memberUrl) {
+		t.Errorf("requests = %v, want the project member lookup", server.Requests())
+	}
+
+	for _, method := range []string{"PATCH", "DELETE"} {
+		if server.Received(method + " " + memberUrl) {
+			t.Errorf("requests = %v, did not want %s", server.Requests(), method)
+		}
+	}
+}
+
+// TestProjectBuilder_ListWithoutProjects tests that a 404 from the projects endpoint yields an empty page.
+func TestProjectBuilder_ListWithoutProjects(t *testing.T) {
+	client, _ := test.NewMockServerClient(t, map[string]test.MockResponse{})
+	builder := newProjectBuilder(client, test.MockOrgID)
+
+	parent := &v2.ResourceId{ResourceType: teamResourceType.Id, Resource: testTeamID}
+	resources, nextPage, _, err := builder.List(context.Background(), parent, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(resources) != 0 || nextPage != "" {
+		t.Errorf("List() = %d resources, next page %q; want none", len(resources), nextPage)
+	}
+}
+
+// TestProjectBuilder_GrantRevoke tests changing and removing non-owner project roles against the mock API.
+func TestProjectBuilder_GrantRevoke(t *testing.T) {
+	membersUrl := "/v2/orgs/" + test.MockOrgID + "/teams/" + testTeamID + "/projects/" + testProjectID + "/members"
+	memberUrl := membersUrl + "/" + testUserID
+	orgMemberUrl := "/v2/orgs/" + test.MockOrgID + "/members/" + testUserID
+
+	project, err := projectResource(&miro.Project{Id: testProjectID, Name: "Platform"}, &v2.ResourceId{ResourceType: teamResourceType.Id, Resource: testTeamID})
+	if err != nil {
+		t.Fatalf("projectResource() error = %v", err)
+	}
+	principal := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: testUserID}}
+	entitlement := func(role string) *v2.Entitlement {
+		return &v2.Entitlement{Id: "project:" + testProjectID + ":" + role, Resource: project}
+	}
+
+	tests := []struct {
+		name          string
+		revoke        bool
+		role          string
+		routes        map[string]test.MockResponse
+		wantAnnotated bool
+		wantRequest   string
+	}{
+		{
+			name: "grant to non-member",
+			role: editorProjectRole,
+			routes: map[string]test.MockResponse{
+				"GET " + orgMemberUrl: {File: "organization_user_success.json"},
+				"POST " + membersUrl:  {Status: 201, File: "project_member_editor_success.json"},
+			},
+			wantRequest: "POST " + membersUrl,
+		},
+		{
+			name: "change role of member",
+			role: viewerProjectRole,
+			routes: map[string]test.MockResponse{
+				"GET " + memberUrl:   {File: "project_member_editor_success.json"},
+				"PATCH " + memberUrl: {File: "project_member_editor_success.json"},
+			},
+			wantRequest: "PATCH " + memberUrl,
+		},
+		{
+			name: "re-grant existing role",
+			role: editorProjectRole,
+			routes: map[string]test.MockResponse{
+				"GET " + memberUrl: {File: "project_member_editor_success.json"},
+			},
+			wantAnnotated: true,
+		},
+		{
+			name:   "remove member",
+			revoke: true,
+			role:   editorProjectRole,
+			routes: map[string]test.MockResponse{
+				"GET " + memberUrl:    {File: "project_member_editor_success.json"},
+				"DELETE " + memberUrl: {Status: 204},
+			},
+			wantRequest: "DELETE " + memberUrl,
+		},
+		{
+			name:          "re-revoke removed member",
+			revoke:        true,
+			role:          editorProjectRole,
+			wantAnnotated: true,
+		},
+		{
+			name:   "revoke role the member no longer holds",
+			revoke: true,
+			role:   viewerProjectRole,
+			routes: map[string]test.MockResponse{
+				"GET " + memberUrl: {File: "project_member_editor_success.json"},
+			},
+			wantAnnotated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := test.NewMockServerClient(t, tt.routes)
+			builder := newProjectBuilder(client, test.MockOrgID)
+
+			var annos annotations.Annotations
+			var err error
+			var annotated bool
+			if tt.revoke {
+				annos, err = builder.Revoke(context.Background(), &v2.Grant{Entitlement: entitlement(tt.role), Principal: principal})
+				annotated = annos.Contains(&v2.GrantAlreadyRevoked{})
+			} else {
+				_, annos, err = builder.Grant(context.Background(), principal, entitlement(tt.role))
+				annotated = annos.Contains(&v2.GrantAlreadyExists{})
+			}
+			if err != nil {
+				t.Fatalf("error = %v, requests = %v", err, server.Requests())
+			}
+
+			if annotated != tt.wantAnnotated {
+				t.Errorf("already granted or revoked = %v, want %v", annotated, tt.wantAnnotated)
+			}
+
+			for _, method := range []string{"POST " + membersUrl, "PATCH " + memberUrl, "DELETE " + memberUrl} {
+				if got := server.Received(method); got != (method == tt.wantRequest) {
+					t.Errorf("requests = %v, want only the %q change", server.Requests(), tt.wantRequest)
+				}
+			}
+		})
+	}
+}
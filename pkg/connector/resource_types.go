@@ -6,6 +6,12 @@ import (
 
 // The user resource type is for all user objects from the database.
 var (
+	organizationResourceType = &v2.ResourceType{
+		Id:          "organization",
+		DisplayName: "Organization",
+		Description: "Miro organization",
+		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_GROUP},
+	}
 	userResourceType = &v2.ResourceType{
 		Id:          "user",
 		DisplayName: "User",
@@ -24,4 +30,27 @@ var (
 		Description: "Role of Miro organization",
 		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_ROLE},
 	}
+	boardResourceType = &v2.ResourceType{
+		Id:          "board",
+		DisplayName: "Board",
+		Description: "Board of Miro team",
+		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_GROUP},
+	}
+	projectResourceType = &v2.ResourceType{
+		Id:          "project",
+		DisplayName: "Project",
+		Description: "Project of Miro team",
+		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_GROUP},
+	}
+	userGroupResourceType = &v2.ResourceType{
+		Id:          "user_group",
+		DisplayName: "User Group",
+		Description: "User group of Miro organization",
+		Traits:      []v2.ResourceType_Trait{v2.ResourceType_TRAIT_GROUP},
+	}
+	licenseResourceType = &v2.ResourceType{
+		Id:          "license",
+		DisplayName: "License",
+		Description: "License of Miro organization",
+	}
 )
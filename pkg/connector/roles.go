@@ -16,6 +16,7 @@ import (
 
 const (
 	defaultRoleKey = "ORGANIZATION_INTERNAL_USER"
+	adminRoleID    = "organization_internal_admin"
 	assignedRole   = "assigned"
 )
 
@@ -111,6 +112,14 @@ func (r *roleBuilder) Grant(ctx context.Context, principal *v2.Resource, entitle
 		}
 	}
 
+	// Granting another role replaces the admin role, so it demotes an admin.
+	if hasScimRole(scimUser, roleDefinitions[adminRoleID].RoleKey) {
+		annos, err = r.ensureNotLastOrganizationAdmin(ctx, userID)
+		if err != nil {
+			return nil, annos, err
+		}
+	}
+
 	_, annos, err = r.client.UpdateUserRole(ctx, userID, roleKey)
 	if err != nil {
 		return nil, annos, fmt.Errorf("failed to update user role for user %s: %w", userID, err)
@@ -152,6 +161,13 @@ func (r *roleBuilder) Revoke(ctx context.Context, g *v2.Grant) (annotations.Anno
 		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
 	}
 
+	if roleID == adminRoleID {
+		annos, err = r.ensureNotLastOrganizationAdmin(ctx, userID)
+		if err != nil {
+			return annos, err
+		}
+	}
+
 	_, annos, err = r.client.UpdateUserRole(ctx, userID, defaultRoleKey)
 	if err != nil {
 		return annos, fmt.Errorf("failed to set default role for user %s: %w", userID, err)
@@ -160,10 +176,72 @@ func (r *roleBuilder) Revoke(ctx context.Context, g *v2.Grant) (annotations.Anno
 	return annos, nil
 }
 
+// ensureNotLastOrganizationAdmin refuses to demote a user when they are the only active organization admin.
+// Demoting a deactivated admin is allowed, since it does not change the number of usable admins.
+func (r *roleBuilder) ensureNotLastOrganizationAdmin(ctx context.Context, userID string) (annotations.Annotations, error) {
+	// Two active admins are enough to know the demotion leaves at least one usable admin behind.
+	response, annos, err := r.client.GetOrganizationMembers(
+		ctx,
+		r.organizationId,
+		"",
+		2,
+		miro.WithQueryParam("role", adminRoleID),
+		miro.WithQueryParam("active", "true"),
+	)
+	if err != nil {
+		return annos, fmt.Errorf("failed to get organization admins: %w", err)
+	}
+
+	if isActiveOrganizationAdmin(response.Data, userID) && countOrganizationAdmins(response.Data) < 2 {
+		return annos, fmt.Errorf(
+			"baton-miro: refusing to demote user %s because they are the only organization admin; grant the %s role to another user first",
+			userID,
+			roleDefinitions[adminRoleID].DisplayName,
+		)
+	}
+
+	return annos, nil
+}
+
+// isActiveOrganizationAdmin reports whether the user is among the given active organization admins.
+func isActiveOrganizationAdmin(users []miro.User, userID string) bool {
+	for _, user := range users {
+		if user.Id == userID && user.Role == adminRoleID && user.Active {
+			return true
+		}
+	}
+
+	return false
+}
+
+// countOrganizationAdmins returns the number of active organization admins among the given users.
+func countOrganizationAdmins(users []miro.User) int {
+	admins := 0
+	for _, user := range users {
+		if user.Role == adminRoleID && user.Active {
+			admins++
+		}
+	}
+
+	return admins
+}
+
+// hasScimRole reports whether a SCIM user holds the given role key.
+func hasScimRole(user *miro.ScimUser, roleKey string) bool {
+	for _, userRole := range user.Roles {
+		if strings.EqualFold(userRole.Value, roleKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // newRoleBuilder creates a new role builder.
-func newRoleBuilder(client *miro.Client) *roleBuilder {
+func newRoleBuilder(client *miro.Client, organizationId string) *roleBuilder {
 	return &roleBuilder{
-		client:       client,
-		resourceType: roleResourceType,
+		client:         client,
+		resourceType:   roleResourceType,
+		organizationId: organizationId,
 	}
 }
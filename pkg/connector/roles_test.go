@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/conductorone/baton-miro/pkg/miro"
+	"github.com/conductorone/baton-miro/test"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
 )
@@ -156,3 +158,90 @@ func TestRoleBuilder_Grants_EmptyResult(t *testing.T) {
 		t.Errorf("Grants() length = %v, want 0 (role grants are now emitted from user resources)", len(grants))
 	}
 }
+
+// TestCountOrganizationAdmins tests counting the organization admins among users.
+func TestCountOrganizationAdmins(t *testing.T) {
+	users := []miro.User{
+		{Id: "user-1", Role: "organization_internal_admin", Active: true},
+		{Id: "user-2", Role: "organization_internal_user", Active: true},
+		{Id: "user-3", Role: "organization_internal_admin", Active: true},
+		{Id: "user-4", Role: "organization_internal_admin", Active: false},
+	}
+
+	if got := countOrganizationAdmins(users); got != 2 {
+		t.Errorf("countOrganizationAdmins() = %v, want 2", got)
+	}
+
+	if got := countOrganizationAdmins(users[1:2]); got != 0 {
+		t.Errorf("countOrganizationAdmins() = %v, want 0", got)
+	}
+}
+
+// TestHasScimRole tests the SCIM role check.
+func TestHasScimRole(t *testing.T) {
+	user := &miro.ScimUser{
+		Roles: []miro.ScimUserRole{{Value: "ORGANIZATION_INTERNAL_ADMIN", Primary: true}},
+	}
+
+	if !hasScimRole(user, "ORGANIZATION_INTERNAL_ADMIN") {
+		t.Error("hasScimRole() = false, want true for the admin role")
+	}
+
+	if hasScimRole(user, defaultRoleKey) {
+		t.Error("hasScimRole() = true, want false for the default role")
+	}
+}
+
+// TestRoleBuilder_RevokeLastAdminGuard tests that only the sole active admin is protected from demotion.
+func TestRoleBuilder_RevokeLastAdminGuard(t *testing.T) {
+	userUrl := "/api/v1/scim/Users/" + testUserID
+
+	tests := []struct {
+		name      string
+		admins    string
+		wantErr   bool
+		wantPatch bool
+	}{
+		{
+			name:    "sole active admin next to a deactivated admin",
+			admins:  "organization_admins_success.json",
+			wantErr: true,
+		},
+		{
+			name:      "deactivated admin next to another active admin",
+			admins:    "organization_other_admin_success.json",
+			wantPatch: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := test.NewMockServerClient(t, map[string]test.MockResponse{
+				"GET " + userUrl: {File: "scim_user_admin_success.json"},
+				"GET /v2/orgs/" + test.MockOrgID + "/members": {File: tt.admins},
+				"PATCH " + userUrl: {File: "scim_user_success.json"},
+			})
+			builder := newRoleBuilder(client, test.MockOrgID)
+
+			g := &v2.Grant{
+				Entitlement: &v2.Entitlement{
+					Resource: &v2.Resource{Id: &v2.ResourceId{ResourceType: roleResourceType.Id, Resource: adminRoleID}},
+				},
+				Principal: &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: testUserID}},
+			}
+
+			_, err := builder.Revoke(context.Background(), g)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Revoke() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !server.Received("GET " + userUrl) {
+				t.Errorf("Revoke() requests = %v, want the SCIM user lookup", server.Requests())
+			}
+
+			if got := server.Received("PATCH " + userUrl); got != tt.wantPatch {
+				t.Errorf("Revoke() requests = %v, want demotion %v", server.Requests(), tt.wantPatch)
+			}
+		})
+	}
+}
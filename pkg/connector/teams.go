@@ -2,8 +2,8 @@ package connector
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/conductorone/baton-miro/pkg/miro"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
@@ -20,6 +20,12 @@ type teamBuilder struct {
 	resourceType   *v2.ResourceType
 	client         *miro.Client
 	organizationId string
+	// useScimMembership routes member grants and revokes through SCIM group membership instead of team invitations.
+	useScimMembership bool
+	// forceDelete allows deleting teams that still have projects or boards.
+	forceDelete bool
+	// allowLastAdminRemoval allows revoking or downgrading the last admin of a team.
+	allowLastAdminRemoval bool
 }
 
 const (
@@ -41,16 +47,71 @@ func (o *teamBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
 	return teamResourceType
 }
 
-func teamResource(team *miro.Team) (*v2.Resource, error) {
-	profile := map[string]interface{}{
-		"name": team.Name,
-		"id":   team.Id,
+// teamSettingsProfile flattens the sharing, invitation and copy policies of a team into profile fields.
+func teamSettingsProfile(settings *miro.TeamSettings) map[string]interface{} {
+	profile := map[string]interface{}{}
+	if settings == nil {
+		return profile
 	}
 
+	if s := settings.TeamSharingPolicySettings; s != nil {
+		allowListedDomains := make([]interface{}, 0, len(s.AllowListedDomains))
+		for _, domain := range s.AllowListedDomains {
+			allowListedDomains = append(allowListedDomains, domain)
+		}
+
+		profile["sharing_via_public_link"] = s.SharingViaPublicLink
+		profile["sharing_on_organization"] = s.SharingOnOrganization
+		profile["sharing_on_account"] = s.SharingOnAccount
+		profile["default_board_access"] = s.DefaultBoardAccess
+		profile["default_organization_access"] = s.DefaultOrganizationAccess
+		profile["default_project_access"] = s.DefaultProjectAccess
+		profile["create_asset_access_level"] = s.CreateAssetAccessLevel
+		profile["move_board_to_account"] = s.MoveBoardToAccount
+		profile["restrict_allowed_domains"] = s.RestrictAllowedDomains
+		profile["allow_listed_domains"] = allowListedDomains
+	}
+
+	if s := settings.TeamInvitationSettings; s != nil {
+		profile["invite_external_users"] = s.InviteExternalUsers
+		profile["who_can_invite"] = s.WhoCanInvite
+	}
+
+	if s := settings.TeamCopyAccessLevelSettings; s != nil {
+		profile["copy_access_level"] = s.CopyAccessLevel
+		profile["copy_access_level_limitation"] = s.CopyAccessLevelLimitation
+	}
+
+	if s := settings.TeamAccountDiscoverySettings; s != nil {
+		profile["account_discovery"] = s.AccountDiscovery
+	}
+
+	if s := settings.TeamCollaborationSettings; s != nil {
+		profile["co_owner_role"] = s.CoOwnerRole
+	}
+
+	return profile
+}
+
+func teamResource(team *miro.Team, settings *miro.TeamSettings, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	profile := teamSettingsProfile(settings)
+	profile["name"] = team.Name
+	profile["id"] = team.Id
+
 	teamTraitOptions := []rs.GroupTraitOption{
 		rs.WithGroupProfile(profile),
 	}
-	resource, err := rs.NewGroupResource(team.Name, teamResourceType, team.Id, teamTraitOptions)
+	resource, err := rs.NewGroupResource(
+		team.Name,
+		teamResourceType,
+		team.Id,
+		teamTraitOptions,
+		rs.WithParentResourceID(parentResourceID),
+		rs.WithAnnotation(
+			&v2.ChildResourceType{ResourceTypeId: boardResourceType.Id},
+			&v2.ChildResourceType{ResourceTypeId: projectResourceType.Id},
+		),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -59,16 +120,23 @@ func teamResource(team *miro.Team) (*v2.Resource, error) {
 }
 
 // newTeamBuilder creates a new team builder.
-func newTeamBuilder(client *miro.Client, organizationId string) *teamBuilder {
+func newTeamBuilder(client *miro.Client, organizationId string, useScimMembership bool, forceDelete bool, allowLastAdminRemoval bool) *teamBuilder {
 	return &teamBuilder{
-		resourceType:   teamResourceType,
-		client:         client,
-		organizationId: organizationId,
+		resourceType:          teamResourceType,
+		client:                client,
+		organizationId:        organizationId,
+		useScimMembership:     useScimMembership,
+		forceDelete:           forceDelete,
+		allowLastAdminRemoval: allowLastAdminRemoval,
 	}
 }
 
 // List returns the teams for an organization.
-func (g *teamBuilder) List(ctx context.Context, _ *v2.ResourceId, pagination *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+func (g *teamBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, pagination *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	if parentResourceID == nil {
+		return nil, "", nil, nil
+	}
+
 	bag, cursor, err := parsePageToken(pagination.Token, &v2.ResourceId{ResourceType: g.resourceType.Id})
 	if err != nil {
 		return nil, "", nil, wrapError(err, "failed to parse page token")
@@ -79,10 +147,27 @@ func (g *teamBuilder) List(ctx context.Context, _ *v2.ResourceId, pagination *pa
 		return nil, "", annos, wrapError(err, "failed to get teams")
 	}
 
+	l := ctxzap.Extract(ctx)
+
 	var resources []*v2.Resource
 	for _, team := range response.Data {
 		team := team
-		resource, err := teamResource(&team)
+		// Settings are only profile details, so a team whose settings cannot be read is still listed without them.
+		settings, settingsAnnos, err := g.client.GetTeamSettings(ctx, g.organizationId, team.Id)
+		if err != nil {
+			if !isPermissionDeniedError(err) && !isNotFoundError(err) {
+				return nil, "", settingsAnnos, wrapError(err, "failed to get team settings")
+			}
+
+			l.Warn(
+				"baton-miro: listing team without settings, settings could not be fetched",
+				zap.String("team_id", team.Id),
+				zap.Error(err),
+			)
+			settings = nil
+		}
+
+		resource, err := teamResource(&team, settings, parentResourceID)
 		if err != nil {
 			return nil, "", annos, wrapError(err, "failed to create team resource")
 		}
@@ -120,17 +205,51 @@ func (o *teamBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *
 	return rv, "", nil, nil
 }
 
-// Grants always returns an empty slice for users since they don't have any entitlements.
-func (o *teamBuilder) Grants(ctx context.Context, resource *v2.Resource, pagination *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
-	bag, cursor, err := parsePageToken(pagination.Token, &v2.ResourceId{ResourceType: o.resourceType.Id})
+// Grants returns a grant for each member of a team, followed by an expandable grant for each user group assigned to the team.
+func (o *teamBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	bag := &pagination.Bag{}
+	err := bag.Unmarshal(pToken.Token)
 	if err != nil {
 		return nil, "", nil, wrapError(err, "failed to parse page token")
 	}
 
+	if bag.Current() == nil {
+		bag.Push(pagination.PageState{ResourceTypeID: userGroupResourceType.Id})
+		bag.Push(pagination.PageState{ResourceTypeID: userResourceType.Id})
+	}
+
+	var (
+		grants []*v2.Grant
+		cursor string
+		annos  annotations.Annotations
+	)
+	switch bag.ResourceTypeID() {
+	case userResourceType.Id:
+		grants, cursor, annos, err = o.memberGrants(ctx, resource, bag.PageToken())
+	case userGroupResourceType.Id:
+		grants, cursor, annos, err = o.userGroupGrants(ctx, resource, bag.PageToken())
+	default:
+		return nil, "", nil, fmt.Errorf("baton-miro: unexpected page state %s", bag.ResourceTypeID())
+	}
+	if err != nil {
+		return nil, "", annos, err
+	}
+
+	nextCursor, err := handleNextPage(bag, cursor)
+	if err != nil {
+		return nil, "", annos, wrapError(err, "failed to create next page cursor")
+	}
+
+	return grants, nextCursor, annos, nil
+}
+
+// memberGrants returns a grant for each member of a team.
+func (o *teamBuilder) memberGrants(ctx context.Context, resource *v2.Resource, cursor string) ([]*v2.Grant, string, annotations.Annotations, error) {
 	response, annos, err := o.client.GetTeamMembers(ctx, o.organizationId, resource.Id.Resource, cursor, resourcePageSize)
 	if err != nil {
 		return nil, "", annos, wrapError(err, "failed to get team members")
 	}
+
 	var grants []*v2.Grant
 	for _, member := range response.Data {
 		if !contains(teamRoles, member.Role) {
@@ -146,20 +265,61 @@ func (o *teamBuilder) Grants(ctx context.Context, resource *v2.Resource, paginat
 		grants = append(grants, g)
 	}
 
-	if response.Cursor == "" {
-		return grants, "", annos, nil
-	}
+	return grants, response.Cursor, annos, nil
+}
 
-	nextCursor, err := handleNextPage(bag, response.Cursor)
+// userGroupGrants returns a grant for each user group assigned to a team, expanded to the members of the user group.
+func (o *teamBuilder) userGroupGrants(ctx context.Context, resource *v2.Resource, cursor string) ([]*v2.Grant, string, annotations.Annotations, error) {
+	response, annos, err := o.client.GetTeamUserGroups(ctx, o.organizationId, resource.Id.Resource, cursor, resourcePageSize)
 	if err != nil {
-		return nil, "", annos, wrapError(err, "failed to create next page cursor")
+		return nil, "", annos, wrapError(err, "failed to get team user groups")
+	}
+
+	var grants []*v2.Grant
+	for _, userGroup := range response.Data {
+		userGroup := userGroup
+		g, err := teamUserGroupGrant(resource, &userGroup)
+		if err != nil {
+			return nil, "", annos, err
+		}
+
+		grants = append(grants, g)
 	}
 
-	return grants, nextCursor, nil, nil
+	return grants, response.Cursor, annos, nil
 }
 
-// Grant invites a user to a team.
-func (o *teamBuilder) Grant(ctx context.Context, principial *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+// teamUserGroupGrant returns the grant of a team role to a user group, expandable to the members of the user group.
+func teamUserGroupGrant(resource *v2.Resource, userGroup *miro.TeamUserGroup) (*v2.Grant, error) {
+	role := userGroup.Role
+	if role == "" {
+		role = memberTeamRole
+	}
+	if !contains(teamRoles, role) {
+		return nil, fmt.Errorf("baton-miro: user group %s has invalid team role %s", userGroup.Id, role)
+	}
+
+	userGroupResource := &v2.Resource{
+		Id: &v2.ResourceId{
+			ResourceType: userGroupResourceType.Id,
+			Resource:     userGroup.Id,
+		},
+	}
+
+	g := grant.NewGrant(
+		resource,
+		role,
+		userGroupResource.Id,
+		grant.WithAnnotation(&v2.GrantExpandable{
+			EntitlementIds: []string{ent.NewEntitlementID(userGroupResource, userGroupMemberEntitlement)},
+		}),
+	)
+
+	return g, nil
+}
+
+// Grant invites a user to a team, or changes the role of an existing team member in place.
+func (o *teamBuilder) Grant(ctx context.Context, principial *v2.Resource, entitlement *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
 	l := ctxzap.Extract(ctx)
 
 	if principial.Id.ResourceType != userResourceType.Id {
@@ -171,12 +331,12 @@ func (o *teamBuilder) Grant(ctx context.Context, principial *v2.Resource, entitl
 			zap.String("principal_type", principial.Id.ResourceType),
 		)
 
-		return nil, err
+		return nil, nil, err
 	}
 
 	role, err := parseTeamRoleFromEntitlementID(entitlement.Id)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if !contains(teamRoles, role) {
 		err := fmt.Errorf("baton-miro: invalid team role %s", role)
@@ -186,23 +346,45 @@ func (o *teamBuilder) Grant(ctx context.Context, principial *v2.Resource, entitl
 			zap.String("role", role),
 		)
 
-		return nil, err
+		return nil, nil, err
 	}
 
-	user, annos, err := o.client.GetOrganizationMember(ctx, o.organizationId, principial.Id.Resource)
-	if err != nil {
-		return annos, wrapError(err, "failed to get user")
+	teamId := entitlement.Resource.Id.Resource
+	userId := principial.Id.Resource
+
+	member, annos, err := o.client.GetTeamMember(ctx, o.organizationId, teamId, userId)
+	if err != nil && !isNotFoundError(err) {
+		return nil, annos, wrapError(err, "failed to get team member")
 	}
 
-	_, annos, err = o.client.InviteTeamMember(ctx, o.organizationId, entitlement.Resource.Id.Resource, user.Email, role)
-	if err != nil {
-		return annos, wrapError(err, "failed to invite user to team")
+	switch {
+	case member == nil:
+		annos, err = o.addTeamMember(ctx, teamId, userId, "", role)
+		if err != nil {
+			return nil, annos, err
+		}
+	case member.Role == role:
+		return nil, annotations.New(&v2.GrantAlreadyExists{}), nil
+	default:
+		if member.Role == adminTeamRole {
+			annos, err = o.ensureNotLastTeamAdmin(ctx, teamId)
+			if err != nil {
+				return nil, annos, err
+			}
+		}
+
+		_, annos, err = o.client.UpdateTeamMember(ctx, o.organizationId, teamId, userId, role)
+		if err != nil {
+			return nil, annos, wrapError(err, "failed to update team member role")
+		}
 	}
 
-	return annos, nil
+	g := grant.NewGrant(entitlement.Resource, role, principial.Id)
+	return []*v2.Grant{g}, annos, nil
 }
 
-// Revoke removes a user from a team.
+// Revoke removes a user from a team when they still hold the revoked role.
+// Revoking the admin role downgrades the user to a team member instead.
 func (g *teamBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
 	l := ctxzap.Extract(ctx)
 
@@ -221,20 +403,258 @@ func (g *teamBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotations.
 		return nil, err
 	}
 
-	_, err := g.client.RemoveTeamMember(ctx, g.organizationId, entitlement.Resource.Id.Resource, principal.Id.Resource)
+	role, err := parseTeamRoleFromEntitlementID(entitlement.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	teamId := entitlement.Resource.Id.Resource
+	userId := principal.Id.Resource
+
+	member, annos, err := g.client.GetTeamMember(ctx, g.organizationId, teamId, userId)
+	if err != nil {
+		if isNotFoundError(err) {
+			return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+		}
+		return annos, wrapError(err, "failed to get team member")
+	}
+
+	if member.Role != role {
+		return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+	}
+
+	if role == adminTeamRole {
+		annos, err = g.ensureNotLastTeamAdmin(ctx, teamId)
+		if err != nil {
+			return annos, err
+		}
+	}
+
+	if remainingRole := remainingTeamRole(role); remainingRole != "" {
+		_, annos, err = g.client.UpdateTeamMember(ctx, g.organizationId, teamId, userId, remainingRole)
+		if err != nil {
+			return annos, wrapError(err, "failed to downgrade team member role")
+		}
+
+		return annos, nil
+	}
+
+	if g.useScimMembership {
+		annos, err = g.client.RemoveScimGroupMember(ctx, teamId, userId)
+		if err != nil {
+			return annos, wrapError(err, "failed to remove user from team group")
+		}
+
+		return annos, nil
+	}
+
+	annos, err = g.client.RemoveTeamMember(ctx, g.organizationId, teamId, userId)
 	if err != nil {
-		return nil, wrapError(err, "failed to remove user from team")
+		return annos, wrapError(err, "failed to remove user from team")
 	}
 
-	return nil, nil
+	return annos, nil
 }
 
-func parseTeamRoleFromEntitlementID(entitlementID string) (string, error) {
-	parts := strings.Split(entitlementID, ":")
-	if len(parts) != 3 {
-		return "", fmt.Errorf("invalid entitlement ID: %s", entitlementID)
+// ensureNotLastTeamAdmin refuses a change that would leave a team without an admin, unless the guardrail is disabled.
+func (o *teamBuilder) ensureNotLastTeamAdmin(ctx context.Context, teamId string) (annotations.Annotations, error) {
+	if o.allowLastAdminRemoval {
+		return nil, nil
+	}
+
+	// Two admins are enough to know the change does not remove the last one.
+	response, annos, err := o.client.GetTeamMembers(ctx, o.organizationId, teamId, "", 2, miro.WithQueryParam("role", adminTeamRole))
+	if err != nil {
+		return annos, wrapError(err, "failed to get team admins")
+	}
+
+	if countTeamAdmins(response.Data) < 2 {
+		return annos, fmt.Errorf("baton-miro: refusing to remove the last admin of team %s, set miro-allow-last-team-admin-removal to override", teamId)
+	}
+
+	return annos, nil
+}
+
+// countTeamAdmins returns the number of admins among the given team members.
+func countTeamAdmins(members []miro.TeamMember) int {
+	admins := 0
+	for _, member := range members {
+		if member.Role == adminTeamRole {
+			admins++
+		}
+	}
+
+	return admins
+}
+
+// remainingTeamRole returns the role a team member keeps when the given role is revoked,
+// or an empty string when revoking the role removes the user from the team.
+func remainingTeamRole(role string) string {
+	if role == adminTeamRole {
+		return memberTeamRole
+	}
+
+	return ""
+}
+
+// addTeamMember adds a user who is not on the team yet. Members are added through SCIM when configured,
+// otherwise the user is invited by email, which is looked up when empty.
+func (o *teamBuilder) addTeamMember(ctx context.Context, teamId string, userId string, email string, role string) (annotations.Annotations, error) {
+	if o.useScimMembership && role == memberTeamRole {
+		return o.grantScimMembership(ctx, teamId, userId)
 	}
 
-	role := parts[2]
-	return role, nil
+	if email == "" {
+		user, userAnnos, err := o.client.GetOrganizationMember(ctx, o.organizationId, userId)
+		if err != nil {
+			return userAnnos, wrapError(err, "failed to get user")
+		}
+		email = user.Email
+	}
+
+	_, annos, err := o.client.InviteTeamMember(ctx, o.organizationId, teamId, email, role)
+	if err != nil {
+		return annos, wrapError(err, "failed to invite user to team")
+	}
+
+	return annos, nil
+}
+
+// ensureTeamMember adds a user to a team as a member, unless they already belong to the team.
+func (o *teamBuilder) ensureTeamMember(ctx context.Context, teamId string, userId string, email string) (annotations.Annotations, error) {
+	member, annos, err := o.client.GetTeamMember(ctx, o.organizationId, teamId, userId)
+	if err != nil && !isNotFoundError(err) {
+		return annos, wrapError(err, "failed to get team member")
+	}
+
+	if member != nil {
+		return annos, nil
+	}
+
+	return o.addTeamMember(ctx, teamId, userId, email, memberTeamRole)
+}
+
+// grantScimMembership adds a user to a team through SCIM group membership, which does not send an invitation.
+func (o *teamBuilder) grantScimMembership(ctx context.Context, teamId string, userId string) (annotations.Annotations, error) {
+	group, annos, err := o.client.GetScimGroup(ctx, teamId)
+	if err != nil {
+		return annos, wrapError(err, "failed to get team group")
+	}
+
+	if scimGroupHasMember(group, userId) {
+		return annotations.New(&v2.GrantAlreadyExists{}), nil
+	}
+
+	annos, err = o.client.AddScimGroupMember(ctx, teamId, userId)
+	if err != nil {
+		return annos, wrapError(err, "failed to add user to team group")
+	}
+
+	return annos, nil
+}
+
+// scimGroupHasMember reports whether a user is a member of a SCIM group.
+func scimGroupHasMember(group *miro.ScimGroup, userId string) bool {
+	for _, member := range group.Members {
+		if member.Value == userId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Create creates a team named after the given resource. When the group profile holds an admin_email,
+// that user is invited to the new team as admin.
+func (o *teamBuilder) Create(ctx context.Context, resource *v2.Resource) (*v2.Resource, annotations.Annotations, error) {
+	name := resource.DisplayName
+	if name == "" {
+		return nil, nil, fmt.Errorf("baton-miro: team name is required")
+	}
+
+	var adminEmail string
+	groupTrait, err := rs.GetGroupTrait(resource)
+	if err == nil {
+		adminEmail, _ = rs.GetProfileStringValue(groupTrait.Profile, "admin_email")
+	}
+
+	team, annos, err := o.client.CreateTeam(ctx, o.organizationId, name)
+	if err != nil {
+		return nil, annos, wrapError(err, "failed to create team")
+	}
+
+	if adminEmail != "" {
+		_, annos, err = o.client.InviteTeamMember(ctx, o.organizationId, team.Id, adminEmail, adminTeamRole)
+		if err != nil {
+			inviteErr := wrapError(err, "failed to invite team admin")
+
+			// Roll back the new team, so that a retry does not leave an orphaned team behind.
+			deleteAnnos, deleteErr := o.client.DeleteTeam(ctx, o.organizationId, team.Id)
+			if deleteErr != nil {
+				return nil, deleteAnnos, errors.Join(inviteErr, wrapError(deleteErr, fmt.Sprintf("failed to delete team %s", team.Id)))
+			}
+
+			return nil, annos, inviteErr
+		}
+	}
+
+	settings, annos, err := o.client.GetTeamSettings(ctx, o.organizationId, team.Id)
+	if err != nil {
+		return nil, annos, wrapError(err, "failed to get team settings")
+	}
+
+	newResource, err := teamResource(team, settings, organizationResourceId(o.organizationId))
+	if err != nil {
+		return nil, annos, wrapError(err, "failed to create team resource")
+	}
+
+	return newResource, annos, nil
+}
+
+// Delete deletes a team. A team that still has projects or boards is only deleted when force delete is enabled.
+func (o *teamBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
+	teamId := resourceId.Resource
+
+	if !o.forceDelete {
+		projects, annos, err := o.client.GetProjects(ctx, o.organizationId, teamId, "", 1)
+		switch {
+		case err == nil:
+			if len(projects.Data) > 0 {
+				return annos, fmt.Errorf("baton-miro: team %s still has projects, set miro-force-delete-teams to delete it anyway", teamId)
+			}
+		case isNotFoundError(err):
+			// The projects endpoint also returns 404 when projects are unavailable, so only a missing team counts as deleted.
+			_, annos, err = o.client.GetTeam(ctx, o.organizationId, teamId)
+			if err != nil {
+				if isNotFoundError(err) {
+					return annos, nil
+				}
+				return annos, wrapError(err, "failed to get team")
+			}
+		default:
+			return annos, wrapError(err, "failed to get team projects")
+		}
+
+		boards, annos, err := o.client.GetBoards(ctx, teamId, 0, 1)
+		if err != nil {
+			return annos, wrapError(err, "failed to get team boards")
+		}
+		if len(boards.Data) > 0 {
+			return annos, fmt.Errorf("baton-miro: team %s still has boards, set miro-force-delete-teams to delete it anyway", teamId)
+		}
+	}
+
+	annos, err := o.client.DeleteTeam(ctx, o.organizationId, teamId)
+	if err != nil {
+		if isNotFoundError(err) {
+			return annos, nil
+		}
+		return annos, wrapError(err, "failed to delete team")
+	}
+
+	return annos, nil
+}
+
+func parseTeamRoleFromEntitlementID(entitlementID string) (string, error) {
+	return parseRoleFromEntitlementID(entitlementID)
 }
@@ -7,6 +7,10 @@ import (
 
 	"github.com/conductorone/baton-miro/pkg/miro"
 	"github.com/conductorone/baton-miro/test"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
 )
 
 const (
@@ -22,11 +26,15 @@ func TestTeamResource(t *testing.T) {
 		Type: "team",
 	}
 
-	resource, err := teamResource(team)
+	resource, err := teamResource(team, nil, organizationResourceId(test.MockOrgID))
 	if err != nil {
 		t.Fatalf("teamResource() error = %v", err)
 	}
 
+	if resource.ParentResourceId.Resource != test.MockOrgID {
+		t.Errorf("teamResource() ParentResourceId.Resource = %v, want %v", resource.ParentResourceId.Resource, test.MockOrgID)
+	}
+
 	if resource.DisplayName != "Engineering Team" {
 		t.Errorf("teamResource() DisplayName = %v, want %v", resource.DisplayName, "Engineering Team")
 	}
@@ -118,3 +126,536 @@ func TestTeamBuilderResourceType(t *testing.T) {
 		t.Errorf("ResourceType() = %v, want %v", result, teamResourceType)
 	}
 }
+
+// TestTeamResourceWithSettings tests that team settings are surfaced in the team profile.
+func TestTeamResourceWithSettings(t *testing.T) {
+	mockData := test.ReadFile("team_settings_success.json")
+
+	var settings miro.TeamSettings
+	err := json.Unmarshal([]byte(mockData), &settings)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal mock team settings data: %v", err)
+	}
+
+	team := &miro.Team{
+		Id:   testTeamID,
+		Name: "Engineering Team",
+	}
+
+	resource, err := teamResource(team, &settings, organizationResourceId(test.MockOrgID))
+	if err != nil {
+		t.Fatalf("teamResource() error = %v", err)
+	}
+
+	groupTrait, err := rs.GetGroupTrait(resource)
+	if err != nil {
+		t.Fatalf("GetGroupTrait() error = %v", err)
+	}
+
+	expected := map[string]string{
+		"name":                    "Engineering Team",
+		"sharing_via_public_link": "allowed",
+		"invite_external_users":   "allowed",
+		"who_can_invite":          "all_members",
+		"copy_access_level":       "anyone",
+	}
+
+	for key, want := range expected {
+		got, ok := rs.GetProfileStringValue(groupTrait.Profile, key)
+		if !ok || got != want {
+			t.Errorf("teamResource() profile %s = %v, want %v", key, got, want)
+		}
+	}
+
+	domains := groupTrait.Profile.GetFields()["allow_listed_domains"].GetListValue().GetValues()
+	if len(domains) != 1 || domains[0].GetStringValue() != "example.com" {
+		t.Errorf("teamResource() profile allow_listed_domains = %v, want [example.com]", domains)
+	}
+}
+
+// TestTeamBuilder_Entitlements tests that team roles are only grantable to users.
+func TestTeamBuilder_Entitlements(t *testing.T) {
+	builder := &teamBuilder{
+		resourceType: teamResourceType,
+	}
+
+	resource := &v2.Resource{
+		Id: &v2.ResourceId{
+			ResourceType: teamResourceType.Id,
+			Resource:     testTeamID,
+		},
+		DisplayName: "Engineering Team",
+	}
+
+	entitlements, _, _, err := builder.Entitlements(context.Background(), resource, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("Entitlements() error = %v", err)
+	}
+
+	for _, entitlement := range entitlements {
+		grantableTo := entitlement.GetGrantableTo()
+		if len(grantableTo) != 1 || grantableTo[0].Id != userResourceType.Id {
+			t.Errorf("Entitlements() %s grantable to %v, want users only", entitlement.Slug, grantableTo)
+		}
+	}
+}
+
+// TestScimGroupHasMember tests the SCIM team membership check.
+func TestScimGroupHasMember(t *testing.T) {
+	mockData := test.ReadFile("scim_group_success.json")
+
+	var group miro.ScimGroup
+	err := json.Unmarshal([]byte(mockData), &group)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal mock SCIM group data: %v", err)
+	}
+
+	if group.Id != testTeamID {
+		t.Errorf("Expected group ID to be %s, got %s", testTeamID, group.Id)
+	}
+
+	if !scimGroupHasMember(&group, testUserID) {
+		t.Errorf("scimGroupHasMember(%s) = false, want true", testUserID)
+	}
+
+	if scimGroupHasMember(&group, "user-999") {
+		t.Errorf("scimGroupHasMember(user-999) = true, want false")
+	}
+}
+
+// TestTeamUserGroupGrant tests that user groups assigned to a team are granted expandable team roles.
+func TestTeamUserGroupGrant(t *testing.T) {
+	mockData := test.ReadFile("team_user_groups_success.json")
+
+	var response miro.GetTeamUserGroupsResponse
+	err := json.Unmarshal([]byte(mockData), &response)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal mock team user groups data: %v", err)
+	}
+
+	team := &v2.Resource{
+		Id: &v2.ResourceId{
+			ResourceType: teamResourceType.Id,
+			Resource:     testTeamID,
+		},
+	}
+
+	for _, userGroup := range response.Data {
+		userGroup := userGroup
+		g, err := teamUserGroupGrant(team, &userGroup)
+		if err != nil {
+			t.Fatalf("teamUserGroupGrant() error = %v", err)
+		}
+
+		if g.Principal.Id.ResourceType != userGroupResourceType.Id || g.Principal.Id.Resource != userGroup.Id {
+			t.Errorf("teamUserGroupGrant() principal = %v, want user group %s", g.Principal.Id, userGroup.Id)
+		}
+
+		if role, _ := parseTeamRoleFromEntitlementID(g.Entitlement.Id); role != memberTeamRole {
+			t.Errorf("teamUserGroupGrant() role = %v, want %v", role, memberTeamRole)
+		}
+
+		expandable := &v2.GrantExpandable{}
+		annos := annotations.Annotations(g.Annotations)
+		ok, err := annos.Pick(expandable)
+		if err != nil || !ok {
+			t.Fatalf("teamUserGroupGrant() missing GrantExpandable annotation")
+		}
+
+		want := "user_group:" + userGroup.Id + ":member"
+		if len(expandable.EntitlementIds) != 1 || expandable.EntitlementIds[0] != want {
+			t.Errorf("teamUserGroupGrant() expandable entitlements = %v, want [%s]", expandable.EntitlementIds, want)
+		}
+	}
+}
+
+// TestTeamBuilder_CreateRequiresName tests that a team cannot be created without a name.
+func TestTeamBuilder_CreateRequiresName(t *testing.T) {
+	builder := &teamBuilder{
+		resourceType: teamResourceType,
+	}
+
+	resource := &v2.Resource{
+		Id: &v2.ResourceId{ResourceType: teamResourceType.Id},
+	}
+
+	if _, _, err := builder.Create(context.Background(), resource); err == nil {
+		t.Error("Create() expected error for team without a name")
+	}
+}
+
+// TestRemainingTeamRole tests that revoking the admin role downgrades instead of removing the member.
+func TestRemainingTeamRole(t *testing.T) {
+	tests := []struct {
+		role     string
+		expected string
+	}{
+		{role: adminTeamRole, expected: memberTeamRole},
+		{role: memberTeamRole, expected: ""},
+		{role: teamGuestTeamRole, expected: ""},
+		{role: nonTeamTeamRole, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			if got := remainingTeamRole(tt.role); got != tt.expected {
+				t.Errorf("remainingTeamRole(%s) = %q, want %q", tt.role, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestTeamBuilder_GrantRejectsNonUsers tests that only users can be granted team roles.
+func TestTeamBuilder_GrantRejectsNonUsers(t *testing.T) {
+	builder := &teamBuilder{
+		resourceType: teamResourceType,
+	}
+
+	principal := &v2.Resource{
+		Id: &v2.ResourceId{
+			ResourceType: userGroupResourceType.Id,
+			Resource:     "group-123",
+		},
+	}
+	entitlement := &v2.Entitlement{
+		Id: "team:" + testTeamID + ":" + memberTeamRole,
+		Resource: &v2.Resource{
+			Id: &v2.ResourceId{
+				ResourceType: teamResourceType.Id,
+				Resource:     testTeamID,
+			},
+		},
+	}
+
+	grants, _, err := builder.Grant(context.Background(), principal, entitlement)
+	if err == nil {
+		t.Error("Grant() expected error for non-user principal")
+	}
+
+	if len(grants) != 0 {
+		t.Errorf("Grant() returned %d grants, want none", len(grants))
+	}
+}
+
+// TestCountTeamAdmins tests counting the admins of a team.
+func TestCountTeamAdmins(t *testing.T) {
+	mockData := test.ReadFile("team_members_success.json")
+
+	var response miro.GetTeamMembersResponse
+	err := json.Unmarshal([]byte(mockData), &response)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal mock team members data: %v", err)
+	}
+
+	if got := countTeamAdmins(response.Data); got != 1 {
+		t.Errorf("countTeamAdmins() = %v, want 1", got)
+	}
+
+	if got := countTeamAdmins(nil); got != 0 {
+		t.Errorf("countTeamAdmins(nil) = %v, want 0", got)
+	}
+}
+
+// TestTeamBuilder_AllowLastAdminRemoval tests that the last admin guardrail can be disabled.
+func TestTeamBuilder_AllowLastAdminRemoval(t *testing.T) {
+	builder := &teamBuilder{
+		resourceType:          teamResourceType,
+		allowLastAdminRemoval: true,
+	}
+
+	if _, err := builder.ensureNotLastTeamAdmin(context.Background(), testTeamID); err != nil {
+		t.Errorf("ensureNotLastTeamAdmin() error = %v, want nil when the guardrail is disabled", err)
+	}
+}
+
+// teamRevokeGrant returns a grant of the given team role to the test user.
+func teamRevokeGrant(role string) *v2.Grant {
+	return &v2.Grant{
+		Entitlement: &v2.Entitlement{
+			Id: "team:" + testTeamID + ":" + role,
+			Resource: &v2.Resource{
+				Id: &v2.ResourceId{
+					ResourceType: teamResourceType.Id,
+					Resource:     testTeamID,
+				},
+			},
+		},
+		Principal: &v2.Resource{
+			Id: &v2.ResourceId{
+				ResourceType: userResourceType.Id,
+				Resource:     testUserID,
+			},
+		},
+	}
+}
+
+// TestTeamBuilder_Revoke tests revoking team roles against a mock Miro API.
+func TestTeamBuilder_Revoke(t *testing.T) {
+	memberUrl := "/v2/orgs/" + test.MockOrgID + "/teams/" + testTeamID + "/members/" + testUserID
+	membersUrl := "/v2/orgs/" + test.MockOrgID + "/teams/" + testTeamID + "/members"
+
+	tests := []struct {
+		name            string
+		role            string
+		routes          map[string]test.MockResponse
+		wantErr         bool
+		wantRevoked     bool
+		wantRequest     string
+		unwantedRequest string
+	}{
+		{
+			name:        "member not found",
+			role:        memberTeamRole,
+			routes:      map[string]test.MockResponse{},
+			wantRevoked: true,
+		},
+		{
+			name: "role mismatch",
+			role: adminTeamRole,
+			routes: map[string]test.MockResponse{
+				"GET " + memberUrl: {File: "team_member_member_success.json"},
+			},
+			wantRevoked:     true,
+			unwantedRequest: "DELETE " + memberUrl,
+		},
+		{
+			name: "member removed",
+			role: memberTeamRole,
+			routes: map[string]test.MockResponse{
+				"GET " + memberUrl:    {File: "team_member_member_success.json"},
+				"DELETE " + memberUrl: {Status: 204},
+			},
+			wantRequest: "DELETE " + memberUrl,
+		},
+		{
+			name: "admin downgraded to member",
+			role: adminTeamRole,
+			routes: map[string]test.MockResponse{
+				"GET " + memberUrl:   {File: "team_member_admin_success.json"},
+				"GET " + membersUrl:  {File: "team_admins_success.json"},
+				"PATCH " + memberUrl: {File: "team_member_member_success.json"},
+			},
+			wantRequest:     "PATCH " + memberUrl,
+			unwantedRequest: "DELETE " + memberUrl,
+		},
+		{
+			name: "last admin kept",
+			role: adminTeamRole,
+			routes: map[string]test.MockResponse{
+				"GET " + memberUrl:  {File: "team_member_admin_success.json"},
+				"GET " + membersUrl: {File: "team_members_success.json"},
+			},
+			wantErr:         true,
+			unwantedRequest: "PATCH " + memberUrl,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := test.NewMockServerClient(t, tt.routes)
+			builder := newTeamBuilder(client, test.MockOrgID, false, false, false)
+
+			annos, err := builder.Revoke(context.Background(), teamRevokeGrant(tt.role))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Revoke() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := annos.Contains(&v2.GrantAlreadyRevoked{}); got != tt.wantRevoked {
+				t.Errorf("Revoke() GrantAlreadyRevoked = %v, want %v", got, tt.wantRevoked)
+			}
+
+			if tt.wantRequest != "" && !server.Received(tt.wantRequest) {
+				t.Errorf("Revoke() requests = %v, want %s", server.Requests(), tt.wantRequest)
+			}
+
+			if tt.unwantedRequest != "" && server.Received(tt.unwantedRequest) {
+				t.Errorf("Revoke() requests = %v, did not want %s", server.Requests(), tt.unwantedRequest)
+			}
+		})
+	}
+}
+
+// TestTeamBuilder_CreateRollsBackOnInviteFailure tests that a new team is deleted when inviting its admin fails.
+func TestTeamBuilder_CreateRollsBackOnInviteFailure(t *testing.T) {
+	teamsUrl := "/v2/orgs/" + test.MockOrgID + "/teams"
+	client, server := test.NewMockServerClient(t, map[string]test.MockResponse{
+		"POST " + teamsUrl: {File: "team_success.json"},
+		"POST " + teamsUrl + "/" + testTeamID + "/members": {Status: 400},
+		"DELETE " + teamsUrl + "/" + testTeamID:            {Status: 204},
+	})
+	builder := newTeamBuilder(client, test.MockOrgID, false, false, false)
+
+	resource, err := rs.NewGroupResource("Engineering Team", teamResourceType, "", []rs.GroupTraitOption{
+		rs.WithGroupProfile(map[string]interface{}{"admin_email": mockUserEmail}),
+	})
+	if err != nil {
+		t.Fatalf("NewGroupResource() error = %v", err)
+	}
+
+	created, _, err := builder.Create(context.Background(), resource)
+	if err == nil {
+		t.Fatal("Create() expected error when the admin invite fails")
+	}
+
+	if created != nil {
+		t.Errorf("Create() = %v, want no team", created)
+	}
+
+	if !server.Received("DELETE " + teamsUrl + "/" + testTeamID) {
+		t.Errorf("Create() requests = %v, want the new team deleted", server.Requests())
+	}
+}
+
+// TestTeamBuilder_DeleteWithoutProjects tests the safety checks when the projects endpoint returns 404.
+func TestTeamBuilder_DeleteWithoutProjects(t *testing.T) {
+	teamUrl := "/v2/orgs/" + test.MockOrgID + "/teams/" + testTeamID
+
+	tests := []struct {
+		name       string
+		routes     map[string]test.MockResponse
+		wantErr    bool
+		wantDelete bool
+	}{
+		{
+			name:   "team already deleted",
+			routes: map[string]test.MockResponse{},
+		},
+		{
+			name: "team still has boards",
+			routes: map[string]test.MockResponse{
+				"GET " + teamUrl:    {File: "team_success.json"},
+				"GET /v2/boards":    {File: "boards_success.json"},
+				"DELETE " + teamUrl: {Status: 204},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := test.NewMockServerClient(t, tt.routes)
+			builder := newTeamBuilder(client, test.MockOrgID, false, false, false)
+
+			_, err := builder.Delete(context.Background(), &v2.ResourceId{ResourceType: teamResourceType.Id, Resource: testTeamID})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !server.Received("GET /v2/boards") {
+				t.Errorf("Delete() requests = %v, want the board check", server.Requests())
+			}
+
+			if server.Received("DELETE "+teamUrl) != tt.wantDelete {
+				t.Errorf("Delete() requests = %v, want delete %v", server.Requests(), tt.wantDelete)
+			}
+		})
+	}
+}
+
+// TestTeamBuilder_ListWithoutSettings tests that teams whose settings cannot be fetched are listed without them.
+func TestTeamBuilder_ListWithoutSettings(t *testing.T) {
+	teamsUrl := "/v2/orgs/" + test.MockOrgID + "/teams"
+	client, _ := test.NewMockServerClient(t, map[string]test.MockResponse{
+		"GET " + teamsUrl:                        {File: "teams_success.json"},
+		"GET " + teamsUrl + "/team-123/settings": {Status: 403},
+		"GET " + teamsUrl + "/team-456/settings": {File: "team_settings_success.json"},
+	})
+	builder := newTeamBuilder(client, test.MockOrgID, false, false, false)
+
+	resources, _, _, err := builder.List(context.Background(), organizationResourceId(test.MockOrgID), &pagination.Token{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(resources) != 2 {
+		t.Fatalf("List() returned %d teams, want 2", len(resources))
+	}
+
+	wantSettings := map[string]bool{"team-123": false, "team-456": true}
+	for _, resource := range resources {
+		groupTrait, err := rs.GetGroupTrait(resource)
+		if err != nil {
+			t.Fatalf("GetGroupTrait() error = %v", err)
+		}
+
+		_, hasSettings := rs.GetProfileStringValue(groupTrait.Profile, "account_discovery")
+		if hasSettings != wantSettings[resource.Id.Resource] {
+			t.Errorf("List() team %s has settings = %v, want %v", resource.Id.Resource, hasSettings, wantSettings[resource.Id.Resource])
+		}
+	}
+}
+
+// TestTeamBuilder_ScimMembership tests that team membership goes through SCIM groups when configured.
+func TestTeamBuilder_ScimMembership(t *testing.T) {
+	memberUrl := "/v2/orgs/" + test.MockOrgID + "/teams/" + testTeamID + "/members/" + testUserID
+	membersUrl := "/v2/orgs/" + test.MockOrgID + "/teams/" + testTeamID + "/members"
+	orgMemberUrl := "/v2/orgs/" + test.MockOrgID + "/members/" + testUserID
+	groupUrl := "/api/v1/scim/Groups/" + testTeamID
+
+	tests := []struct {
+		name            string
+		revoke          bool
+		role            string
+		routes          map[string]test.MockResponse
+		wantRequest     string
+		unwantedRequest string
+	}{
+		{
+			name: "member granted through SCIM",
+			role: memberTeamRole,
+			routes: map[string]test.MockResponse{
+				"GET " + groupUrl:   {File: "scim_group_without_user_success.json"},
+				"PATCH " + groupUrl: {Status: 204},
+			},
+			wantRequest:     "PATCH " + groupUrl,
+			unwantedRequest: "POST " + membersUrl,
+		},
+		{
+			name: "admin invited through REST",
+			role: adminTeamRole,
+			routes: map[string]test.MockResponse{
+				"GET " + orgMemberUrl: {File: "organization_user_success.json"},
+				"POST " + membersUrl:  {Status: 201, File: "team_member_admin_success.json"},
+			},
+			wantRequest:     "POST " + membersUrl,
+			unwantedRequest: "PATCH " + groupUrl,
+		},
+		{
+			name:   "member revoked through SCIM",
+			revoke: true,
+			role:   memberTeamRole,
+			routes: map[string]test.MockResponse{
+				"GET " + memberUrl:  {File: "team_member_member_success.json"},
+				"PATCH " + groupUrl: {Status: 204},
+			},
+			wantRequest:     "PATCH " + groupUrl,
+			unwantedRequest: "DELETE " + memberUrl,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := test.NewMockServerClient(t, tt.routes)
+			builder := newTeamBuilder(client, test.MockOrgID, true, false, false)
+
+			var err error
+			if tt.revoke {
+				_, err = builder.Revoke(context.Background(), teamRevokeGrant(tt.role))
+			} else {
+				grant := teamRevokeGrant(tt.role)
+				_, _, err = builder.Grant(context.Background(), grant.Principal, grant.Entitlement)
+			}
+			if err != nil {
+				t.Fatalf("error = %v, requests = %v", err, server.Requests())
+			}
+
+			if !server.Received(tt.wantRequest) {
+				t.Errorf("requests = %v, want %s", server.Requests(), tt.wantRequest)
+			}
+
+			if server.Received(tt.unwantedRequest) {
+				t.Errorf("requests = %v, did not want %s", server.Requests(), tt.unwantedRequest)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conductorone/baton-miro/pkg/miro"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+type userGroupBuilder struct {
+	resourceType   *v2.ResourceType
+	client         *miro.Client
+	organizationId string
+	// forceDelete allows deleting user groups that are still assigned to teams.
+	forceDelete bool
+}
+
+const userGroupMemberEntitlement = "member"
+
+// ResourceType returns the resource type for the user group builder.
+func (o *userGroupBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return userGroupResourceType
+}
+
+func userGroupResource(userGroup *miro.UserGroup, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	profile := map[string]interface{}{
+		"name":        userGroup.Name,
+		"id":          userGroup.Id,
+		"description": userGroup.Description,
+	}
+
+	userGroupTraitOptions := []rs.GroupTraitOption{
+		rs.WithGroupProfile(profile),
+	}
+	resource, err := rs.NewGroupResource(
+		userGroup.Name,
+		userGroupResourceType,
+		userGroup.Id,
+		userGroupTraitOptions,
+		rs.WithParentResourceID(parentResourceID),
+		rs.WithDescription(userGroup.Description),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// newUserGroupBuilder creates a new user group builder.
+func newUserGroupBuilder(client *miro.Client, organizationId string, forceDelete bool) *userGroupBuilder {
+	return &userGroupBuilder{
+		resourceType:   userGroupResourceType,
+		client:         client,
+		organizationId: organizationId,
+		forceDelete:    forceDelete,
+	}
+}
+
+// List returns the user groups of the organization.
+func (o *userGroupBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	if parentResourceID == nil {
+		return nil, "", nil, nil
+	}
+
+	bag, cursor, err := parsePageToken(pToken.Token, &v2.ResourceId{ResourceType: o.resourceType.Id})
+	if err != nil {
+		return nil, "", nil, wrapError(err, "failed to parse page token")
+	}
+
+	response, annos, err := o.client.GetUserGroups(ctx, o.organizationId, cursor, resourcePageSize)
+	if err != nil {
+		return nil, "", annos, wrapError(err, "failed to get user groups")
+	}
+
+	var resources []*v2.Resource
+	for _, userGroup := range response.Data {
+		userGroup := userGroup
+		resource, err := userGroupResource(&userGroup, parentResourceID)
+		if err != nil {
+			return nil, "", annos, wrapError(err, "failed to create user group resource")
+		}
+
+		resources = append(resources, resource)
+	}
+
+	if response.Cursor == "" {
+		return resources, "", annos, nil
+	}
+
+	nextCursor, err := handleNextPage(bag, response.Cursor)
+	if err != nil {
+		return nil, "", annos, wrapError(err, "failed to create next page cursor")
+	}
+
+	return resources, nextCursor, annos, nil
+}
+
+// Entitlements returns the member entitlement of a user group.
+func (o *userGroupBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	assigmentOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(userResourceType),
+		ent.WithDescription(fmt.Sprintf("Member of %s user group", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s user group %s", resource.DisplayName, userGroupMemberEntitlement)),
+	}
+
+	entitlement := ent.NewAssignmentEntitlement(resource, userGroupMemberEntitlement, assigmentOptions...)
+
+	return []*v2.Entitlement{entitlement}, "", nil, nil
+}
+
+// Grants returns a grant for each member of a user group.
+func (o *userGroupBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	bag, cursor, err := parsePageToken(pToken.Token, &v2.ResourceId{ResourceType: o.resourceType.Id})
+	if err != nil {
+		return nil, "", nil, wrapError(err, "failed to parse page token")
+	}
+
+	response, annos, err := o.client.GetUserGroupMembers(ctx, o.organizationId, resource.Id.Resource, cursor, resourcePageSize)
+	if err != nil {
+		return nil, "", annos, wrapError(err, "failed to get user group members")
+	}
+
+	var grants []*v2.Grant
+	for _, member := range response.Data {
+		userResourceId := &v2.ResourceId{
+			ResourceType: userResourceType.Id,
+			Resource:     member.Id,
+		}
+
+		g := grant.NewGrant(resource, userGroupMemberEntitlement, userResourceId)
+		grants = append(grants, g)
+	}
+
+	if response.Cursor == "" {
+		return grants, "", annos, nil
+	}
+
+	nextCursor, err := handleNextPage(bag, response.Cursor)
+	if err != nil {
+		return nil, "", annos, wrapError(err, "failed to create next page cursor")
+	}
+
+	return grants, nextCursor, annos, nil
+}
+
+// Grant adds a user to a user group.
+func (o *userGroupBuilder) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) ([]*v2.Grant, annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	if principal.Id.ResourceType != userResourceType.Id {
+		err := fmt.Errorf("baton-miro: only users can be added to user group")
+
+		l.Warn(
+			err.Error(),
+			zap.String("principal_id", principal.Id.Resource),
+			zap.String("principal_type", principal.Id.ResourceType),
+		)
+
+		return nil, nil, err
+	}
+
+	groupId := entitlement.Resource.Id.Resource
+	userId := principal.Id.Resource
+
+	_, annos, err := o.client.GetUserGroupMember(ctx, o.organizationId, groupId, userId)
+	if err == nil {
+		return nil, annotations.New(&v2.GrantAlreadyExists{}), nil
+	}
+	if !isNotFoundError(err) {
+		return nil, annos, wrapError(err, "failed to get user group member")
+	}
+
+	user, annos, err := o.client.GetOrganizationMember(ctx, o.organizationId, userId)
+	if err != nil {
+		return nil, annos, wrapError(err, "failed to get user")
+	}
+
+	_, annos, err = o.client.AddUserGroupMember(ctx, o.organizationId, groupId, user.Email)
+	if err != nil {
+		return nil, annos, wrapError(err, "failed to add user to user group")
+	}
+
+	g := grant.NewGrant(entitlement.Resource, userGroupMemberEntitlement, principal.Id)
+	return []*v2.Grant{g}, annos, nil
+}
+
+// Revoke removes a user from a user group.
+func (o *userGroupBuilder) Revoke(ctx context.Context, g *v2.Grant) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	entitlement := g.Entitlement
+	principal := g.Principal
+
+	if principal.Id.ResourceType != userResourceType.Id {
+		err := fmt.Errorf("baton-miro: only users can be removed from user group")
+
+		l.Warn(
+			err.Error(),
+			zap.String("principal_id", principal.Id.Resource),
+			zap.String("principal_type", principal.Id.ResourceType),
+		)
+
+		return nil, err
+	}
+
+	groupId := entitlement.Resource.Id.Resource
+	userId := principal.Id.Resource
+
+	_, annos, err := o.client.GetUserGroupMember(ctx, o.organizationId, groupId, userId)
+	if err != nil {
+		if isNotFoundError(err) {
+			return annotations.New(&v2.GrantAlreadyRevoked{}), nil
+		}
+		return annos, wrapError(err, "failed to get user group member")
+	}
+
+	annos, err = o.client.RemoveUserGroupMember(ctx, o.organizationId, groupId, userId)
+	if err != nil {
+		return annos, wrapError(err, "failed to remove user from user group")
+	}
+
+	return annos, nil
+}
+
+// Create creates a user group from the name and description of the given resource.
+func (o *userGroupBuilder) Create(ctx context.Context, resource *v2.Resource) (*v2.Resource, annotations.Annotations, error) {
+	name := resource.DisplayName
+	if name == "" {
+		return nil, nil, fmt.Errorf("baton-miro: user group name is required")
+	}
+
+	description := resource.Description
+	if description == "" {
+		groupTrait, err := rs.GetGroupTrait(resource)
+		if err == nil {
+			description, _ = rs.GetProfileStringValue(groupTrait.Profile, "description")
+		}
+	}
+
+	userGroup, annos, err := o.client.CreateUserGroup(ctx, o.organizationId, name, description)
+	if err != nil {
+		return nil, annos, wrapError(err, "failed to create user group")
+	}
+
+	newResource, err := userGroupResource(userGroup, organizationResourceId(o.organizationId))
+	if err != nil {
+		return nil, annos, wrapError(err, "failed to create user group resource")
+	}
+
+	return newResource, annos, nil
+}
+
+// Delete deletes a user group. A user group that is still assigned to teams is only deleted when force delete is enabled.
+func (o *userGroupBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
+	if !o.forceDelete {
+		teams, annos, err := o.client.GetUserGroupTeams(ctx, o.organizationId, resourceId.Resource, "", 1)
+		if err != nil {
+			if isNotFoundError(err) {
+				return nil, nil
+			}
+			return annos, wrapError(err, "failed to get user group teams")
+		}
+
+		if len(teams.Data) > 0 {
+			return annos, fmt.Errorf("baton-miro: user group %s is still assigned to team %s, set miro-force-delete-user-groups to delete it anyway", resourceId.Resource, teams.Data[0].Name)
+		}
+	}
+
+	annos, err := o.client.DeleteUserGroup(ctx, o.organizationId, resourceId.Resource)
+	if err != nil {
+		if isNotFoundError(err) {
+			return annos, nil
+		}
+		return annos, wrapError(err, "failed to delete user group")
+	}
+
+	return annos, nil
+}
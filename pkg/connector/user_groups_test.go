@@ -0,0 +1,275 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/conductorone/baton-miro/pkg/miro"
+	"github.com/conductorone/baton-miro/test"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+)
+
+const (
+	testUserGroupID = "group-123"
+)
+
+// TestUserGroupResource tests the user group resource.
+func TestUserGroupResource(t *testing.T) {
+	mockData := test.ReadFile("user_groups_success.json")
+
+	var response miro.GetUserGroupsResponse
+	err := json.Unmarshal([]byte(mockData), &response)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal mock user groups data: %v", err)
+	}
+
+	if len(response.Data) != 2 {
+		t.Fatalf("Expected 2 user groups in mock data, got %d", len(response.Data))
+	}
+
+	resource, err := userGroupResource(&response.Data[0], organizationResourceId(test.MockOrgID))
+	if err != nil {
+		t.Fatalf("userGroupResource() error = %v", err)
+	}
+
+	if resource.Id.Resource != testUserGroupID {
+		t.Errorf("userGroupResource() Id.Resource = %v, want %v", resource.Id.Resource, testUserGroupID)
+	}
+
+	if resource.Id.ResourceType != userGroupResourceType.Id {
+		t.Errorf("userGroupResource() Id.ResourceType = %v, want %v", resource.Id.ResourceType, userGroupResourceType.Id)
+	}
+
+	if resource.DisplayName != "Design Guild" {
+		t.Errorf("userGroupResource() DisplayName = %v, want %v", resource.DisplayName, "Design Guild")
+	}
+}
+
+// TestUserGroupMembersMockData tests the user group members mock data.
+func TestUserGroupMembersMockData(t *testing.T) {
+	mockData := test.ReadFile("user_group_members_success.json")
+
+	var response miro.GetUserGroupMembersResponse
+	err := json.Unmarshal([]byte(mockData), &response)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal mock user group members data: %v", err)
+	}
+
+	if len(response.Data) != 2 {
+		t.Errorf("Expected 2 members in mock data, got %d", len(response.Data))
+	}
+
+	if response.Data[0].Id != testUserID {
+		t.Errorf("Expected first member ID to be %s, got %s", testUserID, response.Data[0].Id)
+	}
+}
+
+// TestUserGroupBuilder_Entitlements tests the member entitlement of a user group.
+func TestUserGroupBuilder_Entitlements(t *testing.T) {
+	builder := &userGroupBuilder{
+		resourceType: userGroupResourceType,
+	}
+
+	resource := &v2.Resource{
+		Id: &v2.ResourceId{
+			ResourceType: userGroupResourceType.Id,
+			Resource:     testUserGroupID,
+		},
+		DisplayName: "Design Guild",
+	}
+
+	entitlements, nextPage, _, err := builder.Entitlements(context.Background(), resource, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("Entitlements() error = %v", err)
+	}
+
+	if nextPage != "" {
+		t.Errorf("Entitlements() nextPage = %v, want empty string", nextPage)
+	}
+
+	if len(entitlements) != 1 || entitlements[0].Slug != userGroupMemberEntitlement {
+		t.Errorf("Entitlements() = %v, want a single %s entitlement", entitlements, userGroupMemberEntitlement)
+	}
+}
+
+// TestUserGroupBuilder_GrantRejectsNonUsers tests that only users can be added to a user group.
+func TestUserGroupBuilder_GrantRejectsNonUsers(t *testing.T) {
+	builder := &userGroupBuilder{
+		resourceType: userGroupResourceType,
+	}
+
+	principal := &v2.Resource{
+		Id: &v2.ResourceId{
+			ResourceType: teamResourceType.Id,
+			Resource:     testTeamID,
+		},
+	}
+	entitlement := &v2.Entitlement{
+		Id: "user_group:" + testUserGroupID + ":" + userGroupMemberEntitlement,
+		Resource: &v2.Resource{
+			Id: &v2.ResourceId{
+				ResourceType: userGroupResourceType.Id,
+				Resource:     testUserGroupID,
+			},
+		},
+	}
+
+	if _, _, err := builder.Grant(context.Background(), principal, entitlement); err == nil {
+		t.Error("Grant() expected error for non-user principal")
+	}
+
+	if _, err := builder.Revoke(context.Background(), &v2.Grant{Entitlement: entitlement, Principal: principal}); err == nil {
+		t.Error("Revoke() expected error for non-user principal")
+	}
+}
+
+// TestUserGroupBuilder_CreateRequiresName tests that a user group cannot be created without a name.
+func TestUserGroupBuilder_CreateRequiresName(t *testing.T) {
+	builder := &userGroupBuilder{
+		resourceType: userGroupResourceType,
+	}
+
+	resource := &v2.Resource{
+		Id:          &v2.ResourceId{ResourceType: userGroupResourceType.Id},
+		Description: "Designers across all product teams",
+	}
+
+	if _, _, err := builder.Create(context.Background(), resource); err == nil {
+		t.Error("Create() expected error for user group without a name")
+	}
+}
+
+// TestUserGroupBuilder_GrantRevoke tests that user group membership changes are idempotent against the mock API.
+func TestUserGroupBuilder_GrantRevoke(t *testing.T) {
+	membersUrl := "/v2/orgs/" + test.MockOrgID + "/groups/" + testUserGroupID + "/members"
+	memberUrl := membersUrl + "/" + testUserID
+	orgMemberUrl := "/v2/orgs/" + test.MockOrgID + "/members/" + testUserID
+
+	userGroup, err := userGroupResource(&miro.UserGroup{Id: testUserGroupID, Name: "Designers"}, organizationResourceId(test.MockOrgID))
+	if err != nil {
+		t.Fatalf("userGroupResource() error = %v", err)
+	}
+	principal := &v2.Resource{Id: &v2.ResourceId{ResourceType: userResourceType.Id, Resource: testUserID}}
+	entitlement := &v2.Entitlement{Id: "user_group:" + testUserGroupID + ":" + userGroupMemberEntitlement, Resource: userGroup}
+
+	tests := []struct {
+		name          string
+		revoke        bool
+		routes        map[string]test.MockResponse
+		wantAnnotated bool
+		wantRequest   string
+	}{
+		{
+			name: "add non-member",
+			routes: map[string]test.MockResponse{
+				"GET " + orgMemberUrl: {File: "organization_user_success.json"},
+				"POST " + membersUrl:  {Status: 201, File: "user_group_member_success.json"},
+			},
+			wantRequest: "POST " + membersUrl,
+		},
+		{
+			name: "re-add member",
+			routes: map[string]test.MockResponse{
+				"GET " + memberUrl: {File: "user_group_member_success.json"},
+			},
+			wantAnnotated: true,
+		},
+		{
+			name:   "remove member",
+			revoke: true,
+			routes: map[string]test.MockResponse{
+				"GET " + memberUrl:    {File: "user_group_member_success.json"},
+				"DELETE " + memberUrl: {Status: 204},
+			},
+			wantRequest: "DELETE " + memberUrl,
+		},
+		{
+			name:          "re-remove member",
+			revoke:        true,
+			wantAnnotated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := test.NewMockServerClient(t, tt.routes)
+			builder := newUserGroupBuilder(client, test.MockOrgID, false)
+
+			var annotated bool
+			if tt.revoke {
+				annos, err := builder.Revoke(context.Background(), &v2.Grant{Entitlement: entitlement, Principal: principal})
+				if err != nil {
+					t.Fatalf("Revoke() error = %v, requests = %v", err, server.Requests())
+				}
+				annotated = annos.Contains(&v2.GrantAlreadyRevoked{})
+			} else {
+				_, annos, err := builder.Grant(context.Background(), principal, entitlement)
+				if err != nil {
+					t.Fatalf("Grant() error = %v, requests = %v", err, server.Requests())
+				}
+				annotated = annos.Contains(&v2.GrantAlreadyExists{})
+			}
+
+			if annotated != tt.wantAnnotated {
+				t.Errorf("already granted or revoked = %v, want %v", annotated, tt.wantAnnotated)
+			}
+
+			for _, request := range []string{"POST " + membersUrl, "DELETE " + memberUrl} {
+				if got := server.Received(request); got != (request == tt.wantRequest) {
+					t.Errorf("requests = %v, want only the %q change", server.Requests(), tt.wantRequest)
+				}
+			}
+		})
+	}
+}
+
+// TestUserGroupBuilder_Delete tests that user groups still assigned to teams are only deleted when forced.
+func TestUserGroupBuilder_Delete(t *testing.T) {
+	groupUrl := "/v2/orgs/" + test.MockOrgID + "/groups/" + testUserGroupID
+
+	tests := []struct {
+		name        string
+		forceDelete bool
+		teamsFile   string
+		wantErr     bool
+		wantDelete  bool
+	}{
+		{
+			name:      "assigned to teams",
+			teamsFile: "teams_success.json",
+			wantErr:   true,
+		},
+		{
+			name:        "assigned to teams and forced",
+			forceDelete: true,
+			teamsFile:   "teams_success.json",
+			wantDelete:  true,
+		},
+		{
+			name:       "not assigned to teams",
+			teamsFile:  "user_group_teams_empty_success.json",
+			wantDelete: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := test.NewMockServerClient(t, map[string]test.MockResponse{
+				"GET " + groupUrl + "/teams": {File: tt.teamsFile},
+				"DELETE " + groupUrl:         {Status: 204},
+			})
+			builder := newUserGroupBuilder(client, test.MockOrgID, tt.forceDelete)
+
+			_, err := builder.Delete(context.Background(), &v2.ResourceId{ResourceType: userGroupResourceType.Id, Resource: testUserGroupID})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := server.Received("DELETE " + groupUrl); got != tt.wantDelete {
+				t.Errorf("Delete() requests = %v, want DELETE %v", server.Requests(), tt.wantDelete)
+			}
+		})
+	}
+}
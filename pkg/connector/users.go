@@ -3,6 +3,7 @@ package connector
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/conductorone/baton-miro/pkg/miro"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
@@ -11,12 +12,20 @@ import (
 	"github.com/conductorone/baton-sdk/pkg/pagination"
 	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
 	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
 )
 
 type userBuilder struct {
 	resourceType   *v2.ResourceType
 	client         *miro.Client
 	organizationId string
+	// forceDelete allows deleting users that are still active or own boards.
+	forceDelete bool
+	// teams adds new accounts to their initial teams the same way team grants do.
+	teams *teamBuilder
+	// roles guards role changes of existing accounts the same way role grants do.
+	roles *roleBuilder
 }
 
 func (b *userBuilder) CreateAccountCapabilityDetails(_ context.Context) (*v2.CredentialDetailsAccountProvisioning, annotations.Annotations, error) {
@@ -32,11 +41,12 @@ func (o *userBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
 	return userResourceType
 }
 
-func userResource(user *miro.User) (*v2.Resource, error) {
+func userResource(user *miro.User, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
 	profile := map[string]interface{}{
 		"email":   user.Email,
 		"login":   user.Email,
 		"license": user.License,
+		"role":    user.Role,
 	}
 
 	var status v2.UserTrait_Status_Status
@@ -54,13 +64,14 @@ func userResource(user *miro.User) (*v2.Resource, error) {
 	userTraits := []rs.UserTraitOption{
 		rs.WithUserProfile(profile),
 		rs.WithUserLogin(user.Email),
+		rs.WithEmail(user.Email, true),
 		rs.WithStatus(status),
 	}
 	if lastLogin != nil {
 		userTraits = append(userTraits, rs.WithLastLogin(*lastLogin))
 	}
 
-	resource, err := rs.NewUserResource(user.Email, userResourceType, user.Id, userTraits)
+	resource, err := rs.NewUserResource(user.Email, userResourceType, user.Id, userTraits, rs.WithParentResourceID(parentResourceID))
 	if err != nil {
 		return nil, err
 	}
@@ -68,9 +79,106 @@ func userResource(user *miro.User) (*v2.Resource, error) {
 	return resource, nil
 }
 
+// scimUserResource creates a user resource from a SCIM user, with the same profile fields as
+// synced organization members plus the names that only SCIM returns.
+func scimUserResource(user *miro.ScimUser, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	email := scimUserEmail(user)
+
+	displayName := user.DisplayName
+	if displayName == "" {
+		displayName = strings.TrimSpace(user.Name.GivenName + " " + user.Name.FamilyName)
+	}
+	if displayName == "" {
+		displayName = email
+	}
+
+	profile := map[string]interface{}{
+		"email":        email,
+		"login":        email,
+		"license":      scimUserLicense(user),
+		"role":         scimUserRole(user),
+		"first_name":   user.Name.GivenName,
+		"last_name":    user.Name.FamilyName,
+		"display_name": displayName,
+	}
+
+	var status v2.UserTrait_Status_Status
+	if user.Active {
+		status = v2.UserTrait_Status_STATUS_ENABLED
+	} else {
+		status = v2.UserTrait_Status_STATUS_DISABLED
+	}
+
+	userTraits := []rs.UserTraitOption{
+		rs.WithUserProfile(profile),
+		rs.WithUserLogin(email),
+		rs.WithStatus(status),
+	}
+	for _, scimEmail := range user.Emails {
+		userTraits = append(userTraits, rs.WithEmail(scimEmail.Value, scimEmail.Primary))
+	}
+	if len(user.Emails) == 0 {
+		userTraits = append(userTraits, rs.WithEmail(email, true))
+	}
+
+	resource, err := rs.NewUserResource(displayName, userResourceType, user.Id, userTraits, rs.WithParentResourceID(parentResourceID))
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// scimUserEmail returns the primary email of a SCIM user, falling back to the first email and the user name.
+func scimUserEmail(user *miro.ScimUser) string {
+	for _, email := range user.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+	if len(user.Emails) > 0 {
+		return user.Emails[0].Value
+	}
+
+	return user.UserName
+}
+
+// scimUserRole returns the ID of the primary organization role of a SCIM user.
+func scimUserRole(user *miro.ScimUser) string {
+	roleID := ""
+	for _, role := range user.Roles {
+		for _, definition := range roleDefinitions {
+			if strings.EqualFold(role.Value, definition.RoleKey) && (role.Primary || roleID == "") {
+				roleID = definition.ID
+			}
+		}
+	}
+
+	return roleID
+}
+
+// scimUserLicense returns the ID of the license of a SCIM user.
+func scimUserLicense(user *miro.ScimUser) string {
+	if user.MiroUser == nil {
+		return ""
+	}
+
+	for _, definition := range licenseDefinitions {
+		if strings.EqualFold(user.MiroUser.License, definition.ScimKey) {
+			return definition.ID
+		}
+	}
+
+	return ""
+}
+
 // List returns all the users from the database as resource objects.
 // Users include a UserTrait because they are the 'shape' of a standard user.
 func (o *userBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	if parentResourceID == nil {
+		return nil, "", nil, nil
+	}
+
 	bag, cursor, err := parsePageToken(pToken.Token, &v2.ResourceId{ResourceType: o.resourceType.Id})
 	if err != nil {
 		return nil, "", nil, wrapError(err, "failed to parse page token")
@@ -83,7 +191,7 @@ func (o *userBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId,
 
 	var resources []*v2.Resource
 	for _, user := range response.Data {
-		resource, err := userResource(&user)
+		resource, err := userResource(&user, parentResourceID)
 		if err != nil {
 			return nil, "", annos, wrapError(err, "failed to create user resource")
 		}
@@ -103,12 +211,27 @@ func (o *userBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId,
 	return resources, nextCursor, nil, nil
 }
 
+// Get returns a single user, so that status changes are visible without a full sync.
+func (o *userBuilder) Get(ctx context.Context, resourceId *v2.ResourceId, _ *v2.ResourceId) (*v2.Resource, annotations.Annotations, error) {
+	user, annos, err := o.client.GetOrganizationMember(ctx, o.organizationId, resourceId.Resource)
+	if err != nil {
+		return nil, annos, wrapError(err, "failed to get user")
+	}
+
+	resource, err := userResource(user, organizationResourceId(o.organizationId))
+	if err != nil {
+		return nil, annos, wrapError(err, "failed to create user resource")
+	}
+
+	return resource, annos, nil
+}
+
 // Entitlements always returns an empty slice for users.
 func (o *userBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
 	return nil, "", nil, nil
 }
 
-// Grants returns role grants for users.
+// Grants returns role and license grants for users.
 func (o *userBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
 	var grants []*v2.Grant
 
@@ -124,6 +247,10 @@ func (o *userBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken
 		grants = append(grants, roleGrants)
 	}
 
+	if licenseGrant := o.licenseGrant(user, resource); licenseGrant != nil {
+		grants = append(grants, licenseGrant)
+	}
+
 	return grants, "", annos, nil
 }
 
@@ -139,6 +266,169 @@ func (o *userBuilder) CreateAccount(
 	error,
 ) {
 	profile := accountInfo.GetProfile().AsMap()
+	createUserReq, teams, err := accountCreationRequest(profile)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	user, annos, err := o.client.FindUserByUserName(ctx, createUserReq.UserName)
+	if err != nil {
+		return nil, nil, annos, wrapError(err, "failed to look up existing miro user")
+	}
+
+	l := ctxzap.Extract(ctx)
+
+	created := user == nil
+	if created {
+		user, annos, err = o.client.CreateUser(ctx, createUserReq)
+		if err != nil {
+			return nil, nil, annos, wrapError(err, "failed to create miro user")
+		}
+	} else {
+		l.Info(
+			"baton-miro: account already exists, updating the existing user",
+			zap.String("user_id", user.Id),
+			zap.Bool("active", user.Active),
+		)
+
+		user, annos, err = o.updateExistingAccount(ctx, user, createUserReq)
+		if err != nil {
+			return nil, nil, annos, err
+		}
+	}
+
+	// Teams are joined for existing users too, so that retrying after a failed join converges.
+	for _, teamId := range teams {
+		annos, err = o.teams.ensureTeamMember(ctx, teamId, user.Id, createUserReq.UserName)
+		if err != nil {
+			return nil, nil, annos, wrapError(err, fmt.Sprintf("failed to add user to team %s", teamId))
+		}
+	}
+
+	resource, err := scimUserResource(user, organizationResourceId(o.organizationId))
+	if err != nil {
+		return nil, nil, annos, wrapError(err, "failed to create user resource from miro user")
+	}
+
+	// The SDK has no result for existing accounts, so only real creations are flagged as such.
+	return &v2.CreateAccountResponse_SuccessResult{
+		Resource:              resource,
+		IsCreateAccountResult: created,
+	}, nil, annos, nil
+}
+
+// updateExistingAccount reactivates an existing user when needed, and applies the role and license
+// requested for the account. Display name and user type are only set for new users.
+func (o *userBuilder) updateExistingAccount(
+	ctx context.Context,
+	user *miro.ScimUser,
+	createUserReq *miro.CreateUserRequest,
+) (*miro.ScimUser, annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	var annos annotations.Annotations
+	var err error
+
+	if !user.Active {
+		l.Info("baton-miro: reactivating existing deactivated user", zap.String("user_id", user.Id))
+
+		user, annos, err = o.client.ReactivateUser(ctx, user.Id)
+		if err != nil {
+			return nil, annos, wrapError(err, "failed to reactivate existing miro user")
+		}
+	}
+
+	if len(createUserReq.Roles) > 0 && !hasScimRole(user, createUserReq.Roles[0].Value) {
+		// Changing the role of an admin demotes them, so the last admin guard applies.
+		if hasScimRole(user, roleDefinitions[adminRoleID].RoleKey) {
+			annos, err = o.roles.ensureNotLastOrganizationAdmin(ctx, user.Id)
+			if err != nil {
+				return nil, annos, err
+			}
+		}
+
+		l.Info("baton-miro: updating role of existing user", zap.String("user_id", user.Id), zap.String("role", createUserReq.Roles[0].Value))
+
+		user, annos, err = o.client.UpdateUserRole(ctx, user.Id, createUserReq.Roles[0].Value)
+		if err != nil {
+			return nil, annos, wrapError(err, "failed to update role of existing miro user")
+		}
+	}
+
+	if createUserReq.MiroUser != nil && (user.MiroUser == nil || !strings.EqualFold(user.MiroUser.License, createUserReq.MiroUser.License)) {
+		l.Info("baton-miro: updating license of existing user", zap.String("user_id", user.Id), zap.String("license", createUserReq.MiroUser.License))
+
+		user, annos, err = o.client.UpdateUserLicense(ctx, user.Id, createUserReq.MiroUser.License)
+		if err != nil {
+			return nil, annos, wrapError(err, "failed to update license of existing miro user")
+		}
+	}
+
+	return user, annos, nil
+}
+
+// Delete permanently deletes a user through SCIM. Unless force delete is enabled, the user must
+// be deactivated and must not own any boards. Owned boards are found by filtering boards on their
+// owner, which only sees boards visible to the connector's token; boards in teams the token cannot
+// access are not counted.
+func (o *userBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
+	userId := resourceId.Resource
+
+	if !o.forceDelete {
+		user, annos, err := o.client.GetUser(ctx, userId)
+		if err != nil {
+			if isNotFoundError(err) {
+				return nil, nil
+			}
+			return annos, wrapError(err, "failed to get user")
+		}
+
+		boards, annos, err := o.client.GetBoards(ctx, "", 0, 1, miro.WithQueryParam("owner", userId))
+		if err != nil {
+			return annos, wrapError(err, "failed to get boards owned by user")
+		}
+
+		ownedBoards := boards.Total
+		if ownedBoards < int32(len(boards.Data)) {
+			ownedBoards = int32(len(boards.Data))
+		}
+
+		if reasons := userDeletionBlockers(user, ownedBoards); len(reasons) > 0 {
+			return annos, fmt.Errorf(
+				"baton-miro: refusing to delete user %s: %s; resolve this first or set miro-force-delete-users",
+				userId,
+				strings.Join(reasons, " and "),
+			)
+		}
+	}
+
+	annos, err := o.client.DeleteUser(ctx, userId)
+	if err != nil {
+		if isNotFoundError(err) {
+			return annos, nil
+		}
+		return annos, wrapError(err, "failed to delete user")
+	}
+
+	return annos, nil
+}
+
+// userDeletionBlockers returns the reasons a user cannot be safely deleted.
+func userDeletionBlockers(user *miro.ScimUser, ownedBoards int32) []string {
+	var reasons []string
+	if user.Active {
+		reasons = append(reasons, "the user is still active and must be deactivated")
+	}
+	if ownedBoards > 0 {
+		reasons = append(reasons, fmt.Sprintf("the user owns %d boards that must be transferred", ownedBoards))
+	}
+
+	return reasons
+}
+
+// accountCreationRequest builds the SCIM create payload from an account creation profile,
+// and returns the IDs of the teams the new user should join.
+func accountCreationRequest(profile map[string]interface{}) (*miro.CreateUserRequest, []string, error) {
 	requiredFields := map[string]string{
 		"first_name": "first_name is required",
 		"last_name":  "last_name is required",
@@ -147,23 +437,60 @@ func (o *userBuilder) CreateAccount(
 
 	for field, errMsg := range requiredFields {
 		if val, ok := profile[field].(string); !ok || val == "" {
-			return nil, nil, nil, fmt.Errorf("%s", errMsg)
+			return nil, nil, fmt.Errorf("%s", errMsg)
 		}
 	}
 
-	newUser, annos, err := o.client.CreateUser(ctx, profile["email"].(string), profile["first_name"].(string), profile["last_name"].(string))
-	if err != nil {
-		return nil, nil, annos, wrapError(err, "failed to create miro user")
+	createUserReq := &miro.CreateUserRequest{
+		Schemas:  []string{miro.ScimUserSchema},
+		UserName: profile["email"].(string),
+		Name: miro.RequestName{
+			GivenName:  profile["first_name"].(string),
+			FamilyName: profile["last_name"].(string),
+		},
 	}
 
-	resource, err := userResource(newUser)
-	if err != nil {
-		return nil, nil, annos, wrapError(err, "failed to create user resource from miro user")
+	if displayName, ok := profile["display_name"].(string); ok {
+		createUserReq.DisplayName = displayName
 	}
 
-	return &v2.CreateAccountResponse_SuccessResult{
-		Resource: resource,
-	}, nil, annos, nil
+	if userType, ok := profile["user_type"].(string); ok {
+		createUserReq.UserType = userType
+	}
+
+	if role, ok := profile["role"].(string); ok && role != "" {
+		definition, exists := roleDefinitions[role]
+		if !exists {
+			return nil, nil, fmt.Errorf("baton-miro: invalid role %s", role)
+		}
+
+		createUserReq.Roles = []miro.RequestRole{{Value: definition.RoleKey, Primary: true}}
+	}
+
+	if license, ok := profile["license"].(string); ok && license != "" {
+		definition, exists := licenseDefinitions[license]
+		if !exists {
+			return nil, nil, fmt.Errorf("baton-miro: invalid license %s", license)
+		}
+
+		createUserReq.Schemas = append(createUserReq.Schemas, miro.ScimMiroUserSchema)
+		createUserReq.MiroUser = &miro.ScimMiroUserExtension{License: definition.ScimKey}
+	}
+
+	var teams []string
+	if values, ok := profile["teams"].([]interface{}); ok {
+		for _, value := range values {
+			teamId, ok := value.(string)
+			if !ok {
+				return nil, nil, fmt.Errorf("baton-miro: invalid team %v", value)
+			}
+			if teamId != "" {
+				teams = append(teams, teamId)
+			}
+		}
+	}
+
+	return createUserReq, teams, nil
 }
 
 // roleGrants returns grants for the user's role.
@@ -181,10 +508,27 @@ func (o *userBuilder) roleGrants(user *miro.User, resource *v2.Resource) (*v2.Gr
 	return roleGrant, nil
 }
 
-func newUserBuilder(client *miro.Client, organizationId string) *userBuilder {
+// licenseGrant returns the grant for the user's license tier.
+func (o *userBuilder) licenseGrant(user *miro.User, resource *v2.Resource) *v2.Grant {
+	definition, exists := licenseDefinitions[user.License]
+	if !exists {
+		return nil
+	}
+
+	licenseResource := &v2.ResourceId{
+		ResourceType: licenseResourceType.Id,
+		Resource:     definition.ID,
+	}
+	return grant.NewGrant(&v2.Resource{Id: licenseResource}, assignedRole, resource.Id)
+}
+
+func newUserBuilder(client *miro.Client, organizationId string, forceDelete bool, teams *teamBuilder, roles *roleBuilder) *userBuilder {
 	return &userBuilder{
 		resourceType:   userResourceType,
 		client:         client,
 		organizationId: organizationId,
+		forceDelete:    forceDelete,
+		teams:          teams,
+		roles:          roles,
 	}
 }
@@ -7,6 +7,9 @@ import (
 
 	"github.com/conductorone/baton-miro/pkg/miro"
 	"github.com/conductorone/baton-miro/test"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 const (
@@ -47,6 +50,29 @@ func TestUserMockData(t *testing.T) {
 	}
 }
 
+// TestScimUserFilterMockData tests the SCIM user filter mock data used to detect existing users.
+func TestScimUserFilterMockData(t *testing.T) {
+	mockData := test.ReadFile("scim_users_filter_success.json")
+
+	var users miro.GetScimUsersResponse
+	err := json.Unmarshal([]byte(mockData), &users)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal mock user filter data: %v", err)
+	}
+
+	if users.TotalResults != 1 || len(users.Resources) != 1 {
+		t.Fatalf("Expected a single matching user, got %d", len(users.Resources))
+	}
+
+	if users.Resources[0].UserName != mockUserEmail {
+		t.Errorf("Expected username to be %s, got %s", mockUserEmail, users.Resources[0].UserName)
+	}
+
+	if users.Resources[0].Active {
+		t.Error("Expected matching user to be deactivated")
+	}
+}
+
 // TestOrganizationUserMockData tests the organization user mock data.
 func TestOrganizationUserMockData(t *testing.T) {
 	mockData := test.ReadFile("organization_user_success.json")
@@ -244,3 +270,321 @@ func TestRoleDefinitionsConsistency(t *testing.T) {
 		}
 	}
 }
+
+// TestUserBuilder_ListWithoutParent tests that users are only listed under the organization.
+func TestUserBuilder_ListWithoutParent(t *testing.T) {
+	builder := &userBuilder{
+		resourceType: userResourceType,
+	}
+
+	resources, nextPage, _, err := builder.List(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(resources) != 0 || nextPage != "" {
+		t.Errorf("List() without parent = %v resources, next page %q; want none", len(resources), nextPage)
+	}
+}
+
+// TestUserDeletionBlockers tests the safety checks that block deleting a user.
+func TestUserDeletionBlockers(t *testing.T) {
+	tests := []struct {
+		name        string
+		active      bool
+		ownedBoards int32
+		expected    int
+	}{
+		{name: "deactivated user without boards", active: false, ownedBoards: 0, expected: 0},
+		{name: "active user", active: true, ownedBoards: 0, expected: 1},
+		{name: "deactivated user owning boards", active: false, ownedBoards: 3, expected: 1},
+		{name: "active user owning boards", active: true, ownedBoards: 3, expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &miro.ScimUser{Id: testUserID, Active: tt.active}
+
+			if got := userDeletionBlockers(user, tt.ownedBoards); len(got) != tt.expected {
+				t.Errorf("userDeletionBlockers() = %v, want %d reasons", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestAccountCreationRequest tests building the SCIM create payload from an account profile.
+func TestAccountCreationRequest(t *testing.T) {
+	profile := map[string]interface{}{
+		"first_name":   "John",
+		"last_name":    "Doe",
+		"email":        mockUserEmail,
+		"display_name": "Johnny",
+		"role":         "organization_external_user",
+		"license":      "occasional",
+		"user_type":    "Contractor",
+		"teams":        []interface{}{"team-1", "", "team-2"},
+	}
+
+	createReq, teams, err := accountCreationRequest(profile)
+	if err != nil {
+		t.Fatalf("accountCreationRequest() error = %v", err)
+	}
+
+	if createReq.UserName != mockUserEmail || createReq.DisplayName != "Johnny" || createReq.UserType != "Contractor" {
+		t.Errorf("accountCreationRequest() = %+v, unexpected user fields", createReq)
+	}
+
+	if len(createReq.Roles) != 1 || createReq.Roles[0].Value != "ORGANIZATION_EXTERNAL_USER" {
+		t.Errorf("accountCreationRequest() roles = %+v, want ORGANIZATION_EXTERNAL_USER", createReq.Roles)
+	}
+
+	if createReq.MiroUser == nil || createReq.MiroUser.License != "OCCASIONAL" {
+		t.Errorf("accountCreationRequest() license = %+v, want OCCASIONAL", createReq.MiroUser)
+	}
+
+	if len(createReq.Schemas) != 2 || createReq.Schemas[1] != miro.ScimMiroUserSchema {
+		t.Errorf("accountCreationRequest() schemas = %v, want the Miro user extension", createReq.Schemas)
+	}
+
+	if len(teams) != 2 || teams[0] != "team-1" || teams[1] != "team-2" {
+		t.Errorf("accountCreationRequest() teams = %v, want [team-1 team-2]", teams)
+	}
+
+	for _, field := range []string{"role", "license"} {
+		invalid := map[string]interface{}{"first_name": "John", "last_name": "Doe", "email": mockUserEmail, field: "unknown"}
+		if _, _, err := accountCreationRequest(invalid); err == nil {
+			t.Errorf("accountCreationRequest() with invalid %s should fail", field)
+		}
+	}
+}
+
+// TestScimUserResource tests the user resource built from a SCIM create response.
+func TestScimUserResource(t *testing.T) {
+	mockData := test.ReadFile("scim_user_create_success.json")
+
+	var user miro.ScimUser
+	if err := json.Unmarshal([]byte(mockData), &user); err != nil {
+		t.Fatalf("Failed to unmarshal mock user data: %v", err)
+	}
+
+	resource, err := scimUserResource(&user, organizationResourceId(test.MockOrgID))
+	if err != nil {
+		t.Fatalf("scimUserResource() error = %v", err)
+	}
+
+	if resource.Id.Resource != mockUserID {
+		t.Errorf("scimUserResource() ID = %s, want %s", resource.Id.Resource, mockUserID)
+	}
+
+	if resource.DisplayName != "John Doe" {
+		t.Errorf("scimUserResource() display name = %s, want John Doe", resource.DisplayName)
+	}
+
+	userTrait, err := rs.GetUserTrait(resource)
+	if err != nil {
+		t.Fatalf("GetUserTrait() error = %v", err)
+	}
+
+	if userTrait.GetStatus().GetStatus() != v2.UserTrait_Status_STATUS_ENABLED {
+		t.Errorf("scimUserResource() status = %v, want enabled", userTrait.GetStatus().GetStatus())
+	}
+
+	if userTrait.GetLogin() != mockUserEmail {
+		t.Errorf("scimUserResource() login = %s, want %s", userTrait.GetLogin(), mockUserEmail)
+	}
+
+	if len(userTrait.GetEmails()) != 1 || userTrait.GetEmails()[0].GetAddress() != mockUserEmail {
+		t.Errorf("scimUserResource() emails = %v, want %s", userTrait.GetEmails(), mockUserEmail)
+	}
+
+	expectedProfile := map[string]string{
+		"role":       "organization_internal_user",
+		"license":    "full",
+		"first_name": "John",
+		"last_name":  "Doe",
+	}
+	for key, expected := range expectedProfile {
+		if value, _ := rs.GetProfileStringValue(userTrait.GetProfile(), key); value != expected {
+			t.Errorf("scimUserResource() profile %s = %s, want %s", key, value, expected)
+		}
+	}
+}
+
+// TestUserBuilder_CreateAccountJoinsTeams tests that new and existing accounts join their initial teams.
+func TestUserBuilder_CreateAccountJoinsTeams(t *testing.T) {
+	usersUrl := "/api/v1/scim/Users"
+	teamMembersUrl := "/v2/orgs/" + test.MockOrgID + "/teams/" + testTeamID + "/members"
+	groupUrl := "/api/v1/scim/Groups/" + testTeamID
+
+	tests := []struct {
+		name             string
+		useScim          bool
+		role             string
+		routes           map[string]test.MockResponse
+		wantCreated      bool
+		wantRequests     []string
+		unwantedRequests []string
+		wantUserPatches  int
+	}{
+		{
+			name:        "new user invited to team",
+			wantCreated: true,
+			routes: map[string]test.MockResponse{
+				"GET " + usersUrl:        {File: "scim_users_filter_empty_success.json"},
+				"POST " + usersUrl:       {Status: 201, File: "scim_user_success.json"},
+				"POST " + teamMembersUrl: {Status: 201, File: "team_member_member_success.json"},
+			},
+			wantRequests:     []string{"POST " + usersUrl, "POST " + teamMembersUrl},
+			unwantedRequests: []string{"PATCH " + groupUrl},
+		},
+		{
+			name:    "existing deactivated user added through SCIM",
+			useScim: true,
+			routes: map[string]test.MockResponse{
+				"GET " + usersUrl:                      {File: "scim_users_filter_success.json"},
+				"PATCH " + usersUrl + "/" + testUserID: {File: "scim_user_success.json"},
+				"GET " + groupUrl:                      {File: "scim_group_without_user_success.json"},
+				"PATCH " + groupUrl:                    {Status: 204},
+			},
+			wantRequests:     []string{"PATCH " + usersUrl + "/" + testUserID, "PATCH " + groupUrl},
+			unwantedRequests: []string{"POST " + usersUrl, "POST " + teamMembersUrl},
+			wantUserPatches:  1,
+		},
+		{
+			name: "existing deactivated user reactivated with requested role",
+			role: "organization_external_user",
+			routes: map[string]test.MockResponse{
+				"GET " + usersUrl:                          {File: "scim_users_filter_success.json"},
+				"PATCH " + usersUrl + "/" + testUserID:     {File: "scim_user_success.json"},
+				"GET " + teamMembersUrl + "/" + testUserID: {File: "team_member_member_success.json"},
+			},
+			unwantedRequests: []string{"POST " + usersUrl},
+			wantUserPatches:  2,
+		},
+		{
+			name: "existing user already on team",
+			routes: map[string]test.MockResponse{
+				"GET " + usersUrl:                          {File: "scim_users_filter_success.json"},
+				"PATCH " + usersUrl + "/" + testUserID:     {File: "scim_user_success.json"},
+				"GET " + teamMembersUrl + "/" + testUserID: {File: "team_member_member_success.json"},
+			},
+			unwantedRequests: []string{"POST " + usersUrl, "POST " + teamMembersUrl},
+			wantUserPatches:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := structpb.NewStruct(map[string]interface{}{
+				"first_name": "John",
+				"last_name":  "Doe",
+				"email":      mockUserEmail,
+				"role":       tt.role,
+				"teams":      []interface{}{testTeamID},
+			})
+			if err != nil {
+				t.Fatalf("NewStruct() error = %v", err)
+			}
+
+			client, server := test.NewMockServerClient(t, tt.routes)
+			teams := newTeamBuilder(client, test.MockOrgID, tt.useScim, false, false)
+			builder := newUserBuilder(client, test.MockOrgID, false, teams, newRoleBuilder(client, test.MockOrgID))
+
+			response, _, _, err := builder.CreateAccount(context.Background(), &v2.AccountInfo{Profile: profile}, nil)
+			if err != nil {
+				t.Fatalf("CreateAccount() error = %v, requests = %v", err, server.Requests())
+			}
+
+			success, ok := response.(*v2.CreateAccountResponse_SuccessResult)
+			if !ok || success.Resource.Id.Resource != testUserID {
+				t.Fatalf("CreateAccount() = %v, want user %s", response, testUserID)
+			}
+
+			if success.IsCreateAccountResult != tt.wantCreated {
+				t.Errorf("CreateAccount() IsCreateAccountResult = %v, want %v", success.IsCreateAccountResult, tt.wantCreated)
+			}
+
+			userPatches := 0
+			for _, request := range server.Requests() {
+				if request == "PATCH "+usersUrl+"/"+testUserID {
+					userPatches++
+				}
+			}
+			if userPatches != tt.wantUserPatches {
+				t.Errorf("CreateAccount() user PATCH requests = %d, want %d", userPatches, tt.wantUserPatches)
+			}
+
+			for _, request := range tt.wantRequests {
+				if !server.Received(request) {
+					t.Errorf("CreateAccount() requests = %v, want %s", server.Requests(), request)
+				}
+			}
+
+			for _, request := range tt.unwantedRequests {
+				if server.Received(request) {
+					t.Errorf("CreateAccount() requests = %v, did not want %s", server.Requests(), request)
+				}
+			}
+		})
+	}
+}
+
+// TestUserBuilder_Delete tests that only deactivated users without boards are deleted unless forced.
+func TestUserBuilder_Delete(t *testing.T) {
+	userUrl := "/api/v1/scim/Users/" + testUserID
+
+	tests := []struct {
+		name        string
+		forceDelete bool
+		userFile    string
+		boardsFile  string
+		wantErr     bool
+		wantDelete  bool
+	}{
+		{
+			name:       "active user",
+			userFile:   "scim_user_success.json",
+			boardsFile: "boards_empty_success.json",
+			wantErr:    true,
+		},
+		{
+			name:       "deactivated user owning boards",
+			userFile:   "scim_user_inactive_success.json",
+			boardsFile: "boards_success.json",
+			wantErr:    true,
+		},
+		{
+			name:       "deactivated user without boards",
+			userFile:   "scim_user_inactive_success.json",
+			boardsFile: "boards_empty_success.json",
+			wantDelete: true,
+		},
+		{
+			name:        "active user owning boards forced",
+			forceDelete: true,
+			userFile:    "scim_user_success.json",
+			boardsFile:  "boards_success.json",
+			wantDelete:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := test.NewMockServerClient(t, map[string]test.MockResponse{
+				"GET " + userUrl:    {File: tt.userFile},
+				"GET /v2/boards":    {File: tt.boardsFile},
+				"DELETE " + userUrl: {Status: 204},
+			})
+			builder := newUserBuilder(client, test.MockOrgID, tt.forceDelete, nil, nil)
+
+			_, err := builder.Delete(context.Background(), &v2.ResourceId{ResourceType: userResourceType.Id, Resource: testUserID})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := server.Received("DELETE " + userUrl); got != tt.wantDelete {
+				t.Errorf("Delete() requests = %v, want DELETE %v", server.Requests(), tt.wantDelete)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package miro
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+)
+
+type (
+	// BoardUser is the user reference embedded in a board.
+	BoardUser struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}
+	// BoardTeam is the team reference embedded in a board.
+	BoardTeam struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}
+	// Board is the response from the GetBoards endpoint.
+	Board struct {
+		Id          string     `json:"id"`
+		Name        string     `json:"name"`
+		Description string     `json:"description"`
+		Type        string     `json:"type"`
+		ViewLink    string     `json:"viewLink"`
+		CreatedAt   string     `json:"createdAt"`
+		ModifiedAt  string     `json:"modifiedAt"`
+		Team        *BoardTeam `json:"team"`
+		Owner       *BoardUser `json:"owner"`
+	}
+	// GetBoardsResponse is the response from the GetBoards endpoint.
+	GetBoardsResponse struct {
+		Limit  int32   `json:"limit"`
+		Size   int32   `json:"size"`
+		Offset int32   `json:"offset"`
+		Total  int32   `json:"total"`
+		Data   []Board `json:"data"`
+		Type   string  `json:"type"`
+	}
+	// BoardMember is the response from the GetBoardMembers endpoint.
+	BoardMember struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+		Role string `json:"role"`
+		Type string `json:"type"`
+	}
+	// GetBoardMembersResponse is the response from the GetBoardMembers endpoint.
+	GetBoardMembersResponse struct {
+		Limit  int32         `json:"limit"`
+		Size   int32         `json:"size"`
+		Offset int32         `json:"offset"`
+		Total  int32         `json:"total"`
+		Data   []BoardMember `json:"data"`
+		Type   string        `json:"type"`
+	}
+	// ShareBoardBody is the body for the ShareBoard endpoint.
+	ShareBoardBody struct {
+		Emails []string `json:"emails"`
+		Role   string   `json:"role"`
+	}
+	// ShareBoardFailure is a single failed invitation from the ShareBoard endpoint.
+	ShareBoardFailure struct {
+		Email  string `json:"email"`
+		Reason string `json:"reason"`
+	}
+	// ShareBoardResponse is the response from the ShareBoard endpoint.
+	ShareBoardResponse struct {
+		Successful []json.Number       `json:"successful"`
+		Failed     []ShareBoardFailure `json:"failed"`
+	}
+	// UpdateBoardMemberBody is the body for the UpdateBoardMember endpoint.
+	UpdateBoardMemberBody struct {
+		Role string `json:"role"`
+	}
+)
+
+const (
+	BoardsUrl       = "/v2/boards"
+	BoardMembersUrl = "/v2/boards/%s/members"
+)
+
+// GetBoards gets the boards for a given team.
+func (c *Client) GetBoards(ctx context.Context, teamId string, offset int32, limit int32, opts ...ReqOpt) (*GetBoardsResponse, annotations.Annotations, error) {
+	boardsUrl, err := buildResourceURL(BoardsUrl)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requestOpts := []ReqOpt{WithLimit(limit), WithOffset(offset)}
+	if teamId != "" {
+		requestOpts = append(requestOpts, WithQueryParam("team_id", teamId))
+	}
+	requestOpts = append(requestOpts, opts...)
+
+	var boards GetBoardsResponse
+	_, annos, err := c.doRequest(ctx, boardsUrl.String(), http.MethodGet, &boards, nil, requestOpts...)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &boards, annos, nil
+}
+
+// GetBoardMembers gets the members of a given board.
+func (c *Client) GetBoardMembers(ctx context.Context, boardId string, offset int32, limit int32, opts ...ReqOpt) (*GetBoardMembersResponse, annotations.Annotations, error) {
+	boardMembersUrl, err := buildResourceURL(fmt.Sprintf(BoardMembersUrl, boardId))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requestOpts := []ReqOpt{WithLimit(limit), WithOffset(offset)}
+	requestOpts = append(requestOpts, opts...)
+
+	var boardMembers GetBoardMembersResponse
+	_, annos, err := c.doRequest(ctx, boardMembersUrl.String(), http.MethodGet, &boardMembers, nil, requestOpts...)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &boardMembers, annos, nil
+}
+
+// GetBoardMember gets a single member of a given board.
+func (c *Client) GetBoardMember(ctx context.Context, boardId string, memberId string) (*BoardMember, annotations.Annotations, error) {
+	boardMemberUrl, err := buildResourceURL(fmt.Sprintf(BoardMembersUrl, boardId), memberId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var boardMember BoardMember
+	_, annos, err := c.doRequest(ctx, boardMemberUrl.String(), http.MethodGet, &boardMember, nil)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &boardMember, annos, nil
+}
+
+// ShareBoard shares a given board with a user at the given role.
+func (c *Client) ShareBoard(ctx context.Context, boardId string, email string, role string) (*ShareBoardResponse, annotations.Annotations, error) {
+	boardMembersUrl, err := buildResourceURL(fmt.Sprintf(BoardMembersUrl, boardId))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body := ShareBoardBody{
+		Emails: []string{email},
+		Role:   role,
+	}
+
+	var shareBoardResponse ShareBoardResponse
+	_, annos, err := c.doRequest(ctx, boardMembersUrl.String(), http.MethodPost, &shareBoardResponse, body)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	if len(shareBoardResponse.Failed) > 0 {
+		failure := shareBoardResponse.Failed[0]
+		return nil, annos, fmt.Errorf("failed to share board with %s: %s", failure.Email, failure.Reason)
+	}
+
+	return &shareBoardResponse, annos, nil
+}
+
+// UpdateBoardMember updates the role of a member of a given board.
+func (c *Client) UpdateBoardMember(ctx context.Context, boardId string, memberId string, role string) (*BoardMember, annotations.Annotations, error) {
+	boardMemberUrl, err := buildResourceURL(fmt.Sprintf(BoardMembersUrl, boardId), memberId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body := UpdateBoardMemberBody{
+		Role: role,
+	}
+
+	var boardMember BoardMember
+	_, annos, err := c.doRequest(ctx, boardMemberUrl.String(), http.MethodPatch, &boardMember, body)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &boardMember, annos, nil
+}
+
+// RemoveBoardMember removes a member from a given board.
+func (c *Client) RemoveBoardMember(ctx context.Context, boardId string, memberId string) (annotations.Annotations, error) {
+	boardMemberUrl, err := buildResourceURL(fmt.Sprintf(BoardMembersUrl, boardId), memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, annos, err := c.doRequest(ctx, boardMemberUrl.String(), http.MethodDelete, nil, nil)
+	if err != nil {
+		return annos, err
+	}
+
+	return annos, nil
+}
@@ -33,6 +33,14 @@ func WithCursor(cursor string) ReqOpt {
 	return WithQueryParam("cursor", cursor)
 }
 
+// WithOffset adds an offset query parameter to the request.
+func WithOffset(offset int32) ReqOpt {
+	if offset == 0 {
+		return func(req *http.Request) *http.Request { return req }
+	}
+	return WithQueryParam("offset", strconv.Itoa(int(offset)))
+}
+
 // buildResourceURL builds a resource URL from an endpoint and path elements.
 func buildResourceURL(endpoint string, elems ...string) (*url.URL, error) {
 	pathElements := append([]string{endpoint}, elems...)
@@ -38,18 +38,33 @@ type ScimUserName struct {
 	GivenName  string `json:"givenName"`
 }
 
+// ScimMiroUserExtension is the Miro extension of the SCIM user schema.
+type ScimMiroUserExtension struct {
+	License string `json:"license,omitempty"`
+}
+
 // ScimUser is the response from the GetUser endpoint.
 type ScimUser struct {
-	Schemas     []string        `json:"schemas"`
-	Id          string          `json:"id"`
-	UserName    string          `json:"userName"`
-	Name        ScimUserName    `json:"name"`
-	DisplayName string          `json:"displayName"`
-	Active      bool            `json:"active"`
-	UserType    string          `json:"userType"`
-	Emails      []ScimUserEmail `json:"emails"`
-	Groups      []ScimUserGroup `json:"groups"`
-	Roles       []ScimUserRole  `json:"roles"`
+	Schemas     []string               `json:"schemas"`
+	Id          string                 `json:"id"`
+	UserName    string                 `json:"userName"`
+	Name        ScimUserName           `json:"name"`
+	DisplayName string                 `json:"displayName"`
+	Active      bool                   `json:"active"`
+	UserType    string                 `json:"userType"`
+	Emails      []ScimUserEmail        `json:"emails"`
+	Groups      []ScimUserGroup        `json:"groups"`
+	Roles       []ScimUserRole         `json:"roles"`
+	MiroUser    *ScimMiroUserExtension `json:"urn:ietf:params:scim:schemas:extension:miro:2.0:User,omitempty"`
+}
+
+// GetScimUsersResponse is the response from the SCIM Users list endpoint.
+type GetScimUsersResponse struct {
+	Schemas      []string   `json:"schemas"`
+	TotalResults int32      `json:"totalResults"`
+	StartIndex   int32      `json:"startIndex"`
+	ItemsPerPage int32      `json:"itemsPerPage"`
+	Resources    []ScimUser `json:"Resources"`
 }
 
 // PatchOp is the response from the GetUser endpoint.
@@ -62,5 +77,5 @@ type PatchOp struct {
 type PatchOpItem struct {
 	Op    string      `json:"op"`
 	Path  string      `json:"path"`
-	Value interface{} `json:"value"`
+	Value interface{} `json:"value,omitempty"`
 }
@@ -11,9 +11,11 @@ import (
 type (
 	// Organization is the response from the GetOrganization endpoint.
 	Organization struct {
-		Id   string `json:"id"`
-		Name string `json:"name"`
-		Type string `json:"type"`
+		Id                    string `json:"id"`
+		Name                  string `json:"name"`
+		Type                  string `json:"type"`
+		Plan                  string `json:"plan"`
+		FullLicensesPurchased int32  `json:"fullLicensesPurchased"`
 	}
 	// GetOrganizationMembersResponse is the response from the GetOrganizationMembers endpoint.
 	GetOrganizationMembersResponse struct {
@@ -25,9 +27,26 @@ type (
 )
 
 const (
+	OrganizationUrl        = "v2/orgs/%s"
 	OrganizationMembersUrl = "v2/orgs/%s/members"
 )
 
+// GetOrganization gets the details of a given organization.
+func (c *Client) GetOrganization(ctx context.Context, organizationId string) (*Organization, annotations.Annotations, error) {
+	getOrganizationUrl, err := buildResourceURL(fmt.Sprintf(OrganizationUrl, organizationId))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var organization Organization
+	_, annos, err := c.doRequest(ctx, getOrganizationUrl.String(), http.MethodGet, &organization, nil)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &organization, annos, nil
+}
+
 // GetOrganizationMembers gets the organization members for a given organization.
 func (c *Client) GetOrganizationMembers(ctx context.Context, organizationId string, cursor string, limit int32, opts ...ReqOpt) (*GetOrganizationMembersResponse, annotations.Annotations, error) {
 	getOrganizationMembersUrl, err := buildResourceURL(fmt.Sprintf(OrganizationMembersUrl, organizationId))
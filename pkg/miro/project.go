@@ -0,0 +1,179 @@
+package miro
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+)
+
+type (
+	// Project is the response from the GetProjects endpoint.
+	Project struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}
+	// GetProjectsResponse is the response from the GetProjects endpoint.
+	GetProjectsResponse struct {
+		Limit  int32     `json:"limit"`
+		Size   int32     `json:"size"`
+		Cursor string    `json:"cursor"`
+		Data   []Project `json:"data"`
+		Type   string    `json:"type"`
+	}
+	// ProjectMember is the response from the GetProjectMembers endpoint.
+	ProjectMember struct {
+		Id    string `json:"id"`
+		Role  string `json:"role"`
+		Email string `json:"email"`
+		Type  string `json:"type"`
+	}
+	// GetProjectMembersResponse is the response from the GetProjectMembers endpoint.
+	GetProjectMembersResponse struct {
+		Limit  int32           `json:"limit"`
+		Size   int32           `json:"size"`
+		Cursor string          `json:"cursor"`
+		Data   []ProjectMember `json:"data"`
+		Type   string          `json:"type"`
+	}
+	// AddProjectMemberBody is the body for the AddProjectMember endpoint.
+	AddProjectMemberBody struct {
+		Email string `json:"email"`
+		Role  string `json:"role"`
+	}
+	// UpdateProjectMemberBody is the body for the UpdateProjectMember endpoint.
+	UpdateProjectMemberBody struct {
+		Role string `json:"role"`
+	}
+)
+
+const (
+	ProjectsUrl       = "/v2/orgs/%s/teams/%s/projects"
+	ProjectMembersUrl = "/v2/orgs/%s/teams/%s/projects/%s/members"
+)
+
+// GetProjects gets the projects for a given organization and team.
+func (c *Client) GetProjects(ctx context.Context, organizationId string, teamId string, cursor string, limit int32, opts ...ReqOpt) (*GetProjectsResponse, annotations.Annotations, error) {
+	projectsUrl, err := buildResourceURL(fmt.Sprintf(ProjectsUrl, organizationId, teamId))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requestOpts := []ReqOpt{WithLimit(limit)}
+	if cursor != "" {
+		requestOpts = append(requestOpts, WithCursor(cursor))
+	}
+	requestOpts = append(requestOpts, opts...)
+
+	var projects GetProjectsResponse
+	_, annos, err := c.doRequest(ctx, projectsUrl.String(), http.MethodGet, &projects, nil, requestOpts...)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &projects, annos, nil
+}
+
+// GetProjectMembers gets the project members for a given organization, team and project.
+func (c *Client) GetProjectMembers(
+	ctx context.Context,
+	organizationId string,
+	teamId string,
+	projectId string,
+	cursor string,
+	limit int32,
+	opts ...ReqOpt,
+) (*GetProjectMembersResponse, annotations.Annotations, error) {
+	projectMembersUrl, err := buildResourceURL(fmt.Sprintf(ProjectMembersUrl, organizationId, teamId, projectId))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requestOpts := []ReqOpt{WithLimit(limit)}
+	if cursor != "" {
+		requestOpts = append(requestOpts, WithCursor(cursor))
+	}
+	requestOpts = append(requestOpts, opts...)
+
+	var projectMembers GetProjectMembersResponse
+	_, annos, err := c.doRequest(ctx, projectMembersUrl.String(), http.MethodGet, &projectMembers, nil, requestOpts...)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &projectMembers, annos, nil
+}
+
+// GetProjectMember gets a single member of a given project.
+func (c *Client) GetProjectMember(ctx context.Context, organizationId string, teamId string, projectId string, memberId string) (*ProjectMember, annotations.Annotations, error) {
+	projectMemberUrl, err := buildResourceURL(fmt.Sprintf(ProjectMembersUrl, organizationId, teamId, projectId), memberId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var projectMember ProjectMember
+	_, annos, err := c.doRequest(ctx, projectMemberUrl.String(), http.MethodGet, &projectMember, nil)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &projectMember, annos, nil
+}
+
+// AddProjectMember adds a user to a given project with the given role.
+func (c *Client) AddProjectMember(ctx context.Context, organizationId string, teamId string, projectId string, email string, role string) (*ProjectMember, annotations.Annotations, error) {
+	projectMembersUrl, err := buildResourceURL(fmt.Sprintf(ProjectMembersUrl, organizationId, teamId, projectId))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body := AddProjectMemberBody{
+		Email: email,
+		Role:  role,
+	}
+
+	var projectMember ProjectMember
+	_, annos, err := c.doRequest(ctx, projectMembersUrl.String(), http.MethodPost, &projectMember, body)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &projectMember, annos, nil
+}
+
+// UpdateProjectMember updates the role of a member of a given project.
+func (c *Client) UpdateProjectMember(ctx context.Context, organizationId string, teamId string, projectId string, memberId string, role string) (*ProjectMember, annotations.Annotations, error) {
+	projectMemberUrl, err := buildResourceURL(fmt.Sprintf(ProjectMembersUrl, organizationId, teamId, projectId), memberId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body := UpdateProjectMemberBody{
+		Role: role,
+	}
+
+	var projectMember ProjectMember
+	_, annos, err := c.doRequest(ctx, projectMemberUrl.String(), http.MethodPatch, &projectMember, body)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &projectMember, annos, nil
+}
+
+// RemoveProjectMember removes a member from a given project.
+func (c *Client) RemoveProjectMember(ctx context.Context, organizationId string, teamId string, projectId string, memberId string) (annotations.Annotations, error) {
+	projectMemberUrl, err := buildResourceURL(fmt.Sprintf(ProjectMembersUrl, organizationId, teamId, projectId), memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, annos, err := c.doRequest(ctx, projectMemberUrl.String(), http.MethodDelete, nil, nil)
+	if err != nil {
+		return annos, err
+	}
+
+	return annos, nil
+}
@@ -4,9 +4,13 @@ import "fmt"
 
 // CreateUserRequest defines the payload for creating a new user via SCIM.
 type CreateUserRequest struct {
-	Schemas  []string    `json:"schemas"`
-	UserName string      `json:"userName"`
-	Name     RequestName `json:"name"`
+	Schemas     []string               `json:"schemas"`
+	UserName    string                 `json:"userName"`
+	Name        RequestName            `json:"name"`
+	DisplayName string                 `json:"displayName,omitempty"`
+	UserType    string                 `json:"userType,omitempty"`
+	Roles       []RequestRole          `json:"roles,omitempty"`
+	MiroUser    *ScimMiroUserExtension `json:"urn:ietf:params:scim:schemas:extension:miro:2.0:User,omitempty"`
 }
 
 // RequestName defines the name of a user.
@@ -15,6 +19,12 @@ type RequestName struct {
 	GivenName  string `json:"givenName"`
 }
 
+// RequestRole defines the organization role of a user.
+type RequestRole struct {
+	Value   string `json:"value"`
+	Primary bool   `json:"primary"`
+}
+
 // SCIMError defines the error response from the SCIM API.
 type SCIMError struct {
 	Schemas []string `json:"schemas"`
@@ -52,11 +52,78 @@ type (
 		Role   string `json:"role"`
 		UserId string `json:"id"`
 	}
+	// UpdateTeamMemberBody is the body for the UpdateTeamMember endpoint.
+	UpdateTeamMemberBody struct {
+		Role string `json:"role"`
+	}
+	// CreateTeamBody is the body for the CreateTeam endpoint.
+	CreateTeamBody struct {
+		Name string `json:"name"`
+	}
+	// TeamUserGroup is a user group assigned to a team.
+	TeamUserGroup struct {
+		Id     string `json:"id"`
+		Role   string `json:"role"`
+		TeamId string `json:"teamId"`
+		Type   string `json:"type"`
+	}
+	// GetTeamUserGroupsResponse is the response from the GetTeamUserGroups endpoint.
+	GetTeamUserGroupsResponse struct {
+		Limit  int32           `json:"limit"`
+		Size   int32           `json:"size"`
+		Cursor string          `json:"cursor"`
+		Data   []TeamUserGroup `json:"data"`
+		Type   string          `json:"type"`
+	}
+	// TeamAccountDiscoverySettings holds the account discovery settings of a team.
+	TeamAccountDiscoverySettings struct {
+		AccountDiscovery string `json:"accountDiscovery,omitempty"`
+	}
+	// TeamCollaborationSettings holds the collaboration settings of a team.
+	TeamCollaborationSettings struct {
+		CoOwnerRole string `json:"coOwnerRole,omitempty"`
+	}
+	// TeamCopyAccessLevelSettings holds the board copy access settings of a team.
+	TeamCopyAccessLevelSettings struct {
+		CopyAccessLevel           string `json:"copyAccessLevel,omitempty"`
+		CopyAccessLevelLimitation string `json:"copyAccessLevelLimitation,omitempty"`
+	}
+	// TeamInvitationSettings holds the invitation settings of a team.
+	TeamInvitationSettings struct {
+		InviteExternalUsers string `json:"inviteExternalUsers,omitempty"`
+		WhoCanInvite        string `json:"whoCanInvite,omitempty"`
+	}
+	// TeamSharingPolicySettings holds the sharing policy settings of a team.
+	TeamSharingPolicySettings struct {
+		AllowListedDomains        []string `json:"allowListedDomains,omitempty"`
+		CreateAssetAccessLevel    string   `json:"createAssetAccessLevel,omitempty"`
+		DefaultBoardAccess        string   `json:"defaultBoardAccess,omitempty"`
+		DefaultOrganizationAccess string   `json:"defaultOrganizationAccess,omitempty"`
+		DefaultProjectAccess      string   `json:"defaultProjectAccess,omitempty"`
+		MoveBoardToAccount        string   `json:"moveBoardToAccount,omitempty"`
+		RestrictAllowedDomains    string   `json:"restrictAllowedDomains,omitempty"`
+		SharingOnAccount          string   `json:"sharingOnAccount,omitempty"`
+		SharingOnOrganization     string   `json:"sharingOnOrganization,omitempty"`
+		SharingViaPublicLink      string   `json:"sharingViaPublicLink,omitempty"`
+	}
+	// TeamSettings is the response from the GetTeamSettings endpoint.
+	TeamSettings struct {
+		OrganizationId               string                        `json:"organizationId,omitempty"`
+		TeamId                       string                        `json:"teamId,omitempty"`
+		TeamAccountDiscoverySettings *TeamAccountDiscoverySettings `json:"teamAccountDiscoverySettings,omitempty"`
+		TeamCollaborationSettings    *TeamCollaborationSettings    `json:"teamCollaborationSettings,omitempty"`
+		TeamCopyAccessLevelSettings  *TeamCopyAccessLevelSettings  `json:"teamCopyAccessLevelSettings,omitempty"`
+		TeamInvitationSettings       *TeamInvitationSettings       `json:"teamInvitationSettings,omitempty"`
+		TeamSharingPolicySettings    *TeamSharingPolicySettings    `json:"teamSharingPolicySettings,omitempty"`
+		Type                         string                        `json:"type,omitempty"`
+	}
 )
 
 const (
-	TeamsUrl       = "/v2/orgs/%s/teams"
-	TeamMembersUrl = "/v2/orgs/%s/teams/%s/members"
+	TeamsUrl        = "/v2/orgs/%s/teams"
+	TeamMembersUrl  = "/v2/orgs/%s/teams/%s/members"
+	TeamSettingsUrl = "/v2/orgs/%s/teams/%s/settings"
+	TeamGroupsUrl   = "/v2/orgs/%s/teams/%s/groups"
 )
 
 // GetTeams gets the teams for a given organization.
@@ -81,6 +148,57 @@ func (c *Client) GetTeams(ctx context.Context, organizationId string, cursor str
 	return &teams, annos, nil
 }
 
+// CreateTeam creates a team in a given organization.
+func (c *Client) CreateTeam(ctx context.Context, organizationId string, name string) (*Team, annotations.Annotations, error) {
+	teamsUrl, err := buildResourceURL(fmt.Sprintf(TeamsUrl, organizationId))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body := CreateTeamBody{
+		Name: name,
+	}
+
+	var team Team
+	_, annos, err := c.doRequest(ctx, teamsUrl.String(), http.MethodPost, &team, body)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &team, annos, nil
+}
+
+// GetTeam gets a team of a given organization by ID.
+func (c *Client) GetTeam(ctx context.Context, organizationId string, teamId string) (*Team, annotations.Annotations, error) {
+	teamUrl, err := buildResourceURL(fmt.Sprintf(TeamsUrl, organizationId), teamId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var team Team
+	_, annos, err := c.doRequest(ctx, teamUrl.String(), http.MethodGet, &team, nil)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &team, annos, nil
+}
+
+// DeleteTeam deletes a team from a given organization.
+func (c *Client) DeleteTeam(ctx context.Context, organizationId string, teamId string) (annotations.Annotations, error) {
+	teamUrl, err := buildResourceURL(fmt.Sprintf(TeamsUrl, organizationId), teamId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, annos, err := c.doRequest(ctx, teamUrl.String(), http.MethodDelete, nil, nil)
+	if err != nil {
+		return annos, err
+	}
+
+	return annos, nil
+}
+
 // GetTeamMembers gets the team members for a given organization and team.
 func (c *Client) GetTeamMembers(ctx context.Context, organizationId string, teamId string, cursor string, limit int32, opts ...ReqOpt) (*GetTeamMembersResponse, annotations.Annotations, error) {
 	teamMembersUrl, err := buildResourceURL(fmt.Sprintf(TeamMembersUrl, organizationId, teamId))
@@ -103,6 +221,28 @@ func (c *Client) GetTeamMembers(ctx context.Context, organizationId string, team
 	return &teamMembers, annos, nil
 }
 
+// GetTeamUserGroups gets the user groups assigned to a given organization and team.
+func (c *Client) GetTeamUserGroups(ctx context.Context, organizationId string, teamId string, cursor string, limit int32, opts ...ReqOpt) (*GetTeamUserGroupsResponse, annotations.Annotations, error) {
+	teamGroupsUrl, err := buildResourceURL(fmt.Sprintf(TeamGroupsUrl, organizationId, teamId))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requestOpts := []ReqOpt{WithLimit(limit)}
+	if cursor != "" {
+		requestOpts = append(requestOpts, WithCursor(cursor))
+	}
+	requestOpts = append(requestOpts, opts...)
+
+	var teamUserGroups GetTeamUserGroupsResponse
+	_, annos, err := c.doRequest(ctx, teamGroupsUrl.String(), http.MethodGet, &teamUserGroups, nil, requestOpts...)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &teamUserGroups, annos, nil
+}
+
 // InviteTeamMember invites a team member to a given organization and team.
 func (c *Client) InviteTeamMember(ctx context.Context, organizationId string, teamId string, email string, role string) (*InviteTeamMemberResponse, annotations.Annotations, error) {
 	teamMembersUrl, err := buildResourceURL(fmt.Sprintf(TeamMembersUrl, organizationId, teamId))
@@ -124,6 +264,42 @@ func (c *Client) InviteTeamMember(ctx context.Context, organizationId string, te
 	return &inviteTeamMemberResponse, annos, nil
 }
 
+// GetTeamMember gets a single member of a given organization and team.
+func (c *Client) GetTeamMember(ctx context.Context, organizationId string, teamId string, memberId string) (*TeamMember, annotations.Annotations, error) {
+	teamMemberUrl, err := buildResourceURL(fmt.Sprintf(TeamMembersUrl, organizationId, teamId), memberId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var teamMember TeamMember
+	_, annos, err := c.doRequest(ctx, teamMemberUrl.String(), http.MethodGet, &teamMember, nil)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &teamMember, annos, nil
+}
+
+// UpdateTeamMember updates the role of a member of a given organization and team.
+func (c *Client) UpdateTeamMember(ctx context.Context, organizationId string, teamId string, memberId string, role string) (*TeamMember, annotations.Annotations, error) {
+	teamMemberUrl, err := buildResourceURL(fmt.Sprintf(TeamMembersUrl, organizationId, teamId), memberId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body := UpdateTeamMemberBody{
+		Role: role,
+	}
+
+	var teamMember TeamMember
+	_, annos, err := c.doRequest(ctx, teamMemberUrl.String(), http.MethodPatch, &teamMember, body)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &teamMember, annos, nil
+}
+
 // RemoveTeamMember removes a team member from a given organization and team.
 func (c *Client) RemoveTeamMember(ctx context.Context, organizationId string, teamId string, userId string) (annotations.Annotations, error) {
 	teamMembersUrl, err := buildResourceURL(fmt.Sprintf(TeamMembersUrl, organizationId, teamId), userId)
@@ -138,3 +314,35 @@ func (c *Client) RemoveTeamMember(ctx context.Context, organizationId string, te
 
 	return annos, nil
 }
+
+// GetTeamSettings gets the settings of a given organization and team.
+func (c *Client) GetTeamSettings(ctx context.Context, organizationId string, teamId string) (*TeamSettings, annotations.Annotations, error) {
+	teamSettingsUrl, err := buildResourceURL(fmt.Sprintf(TeamSettingsUrl, organizationId, teamId))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var teamSettings TeamSettings
+	_, annos, err := c.doRequest(ctx, teamSettingsUrl.String(), http.MethodGet, &teamSettings, nil)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &teamSettings, annos, nil
+}
+
+// UpdateTeamSettings updates the settings of a given organization and team. Only the non-empty fields of changes are applied.
+func (c *Client) UpdateTeamSettings(ctx context.Context, organizationId string, teamId string, changes *TeamSettings) (*TeamSettings, annotations.Annotations, error) {
+	teamSettingsUrl, err := buildResourceURL(fmt.Sprintf(TeamSettingsUrl, organizationId, teamId))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var teamSettings TeamSettings
+	_, annos, err := c.doRequest(ctx, teamSettingsUrl.String(), http.MethodPatch, &teamSettings, changes)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &teamSettings, annos, nil
+}
@@ -2,7 +2,9 @@ package miro
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/conductorone/baton-sdk/pkg/annotations"
 )
@@ -12,23 +14,20 @@ const (
 	UsersUrl = "/Users"
 )
 
+// ScimUserSchema is the schema URN of the SCIM core user.
+const ScimUserSchema = "urn:ietf:params:scim:schemas:core:2.0:User"
+
+// ScimMiroUserSchema is the schema URN of the Miro SCIM user extension.
+const ScimMiroUserSchema = "urn:ietf:params:scim:schemas:extension:miro:2.0:User"
+
 // CreateUser creates a new user in Miro using the SCIM API.
-func (c *Client) CreateUser(ctx context.Context, email string, firstName string, lastName string) (*User, annotations.Annotations, error) {
+func (c *Client) CreateUser(ctx context.Context, createUserReq *CreateUserRequest) (*ScimUser, annotations.Annotations, error) {
 	createUserUrl, err := buildResourceURL(UsersUrl)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	createUserReq := &CreateUserRequest{
-		Schemas:  []string{"urn:ietf:params:scim:schemas:core:2.0:User"},
-		UserName: email,
-		Name: RequestName{
-			GivenName:  firstName,
-			FamilyName: lastName,
-		},
-	}
-
-	var userResponse User
+	var userResponse ScimUser
 	_, annos, err := c.doScimRequest(ctx, createUserUrl.String(), http.MethodPost, &userResponse, createUserReq)
 	if err != nil {
 		return nil, annos, err
@@ -53,6 +52,32 @@ func (c *Client) GetUser(ctx context.Context, userId string) (*ScimUser, annotat
 	return &userResponse, annos, nil
 }
 
+// FindUserByUserName looks up a user by user name (email) using a SCIM filter.
+// It returns nil when no user matches.
+func (c *Client) FindUserByUserName(ctx context.Context, userName string) (*ScimUser, annotations.Annotations, error) {
+	findUserUrl, err := buildResourceURL(UsersUrl)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter := fmt.Sprintf("userName eq \"%s\"", strings.ReplaceAll(userName, `"`, `\"`))
+	requestOpts := []ReqOpt{
+		WithQueryParam("filter", filter),
+	}
+
+	var usersResponse GetScimUsersResponse
+	_, annos, err := c.doScimRequest(ctx, findUserUrl.String(), http.MethodGet, &usersResponse, nil, requestOpts...)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	if len(usersResponse.Resources) == 0 {
+		return nil, annos, nil
+	}
+
+	return &usersResponse.Resources[0], annos, nil
+}
+
 // ReplaceUser completely replaces a user using the SCIM PUT API.
 func (c *Client) ReplaceUser(ctx context.Context, userId string, user *ScimUser) (*ScimUser, annotations.Annotations, error) {
 	replaceUserUrl, err := buildResourceURL(UsersUrl, userId)
@@ -95,3 +120,81 @@ func (c *Client) UpdateUserRole(ctx context.Context, userId string, role string)
 
 	return &userResponse, annos, nil
 }
+
+// UpdateUserLicense updates the license of a user in Miro using the SCIM API.
+func (c *Client) UpdateUserLicense(ctx context.Context, userId string, license string) (*ScimUser, annotations.Annotations, error) {
+	updateUserLicenseUrl, err := buildResourceURL(UsersUrl, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	patchData := PatchOp{
+		Schemas: []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+		Operations: []PatchOpItem{
+			{
+				Op:    "Replace",
+				Path:  ScimMiroUserSchema + ":license",
+				Value: license,
+			},
+		},
+	}
+
+	var userResponse ScimUser
+	_, annos, err := c.doScimRequest(ctx, updateUserLicenseUrl.String(), http.MethodPatch, &userResponse, &patchData)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &userResponse, annos, nil
+}
+
+// DeleteUser permanently deletes a user in Miro using the SCIM API.
+func (c *Client) DeleteUser(ctx context.Context, userId string) (annotations.Annotations, error) {
+	deleteUserUrl, err := buildResourceURL(UsersUrl, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, annos, err := c.doScimRequest(ctx, deleteUserUrl.String(), http.MethodDelete, nil, nil)
+	if err != nil {
+		return annos, err
+	}
+
+	return annos, nil
+}
+
+// DeactivateUser deactivates a user in Miro using the SCIM API. The user's content is kept.
+func (c *Client) DeactivateUser(ctx context.Context, userId string) (*ScimUser, annotations.Annotations, error) {
+	return c.setUserActive(ctx, userId, false)
+}
+
+// ReactivateUser reactivates a deactivated user in Miro using the SCIM API.
+func (c *Client) ReactivateUser(ctx context.Context, userId string) (*ScimUser, annotations.Annotations, error) {
+	return c.setUserActive(ctx, userId, true)
+}
+
+func (c *Client) setUserActive(ctx context.Context, userId string, active bool) (*ScimUser, annotations.Annotations, error) {
+	updateUserUrl, err := buildResourceURL(UsersUrl, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	patchData := PatchOp{
+		Schemas: []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+		Operations: []PatchOpItem{
+			{
+				Op:    "Replace",
+				Path:  "active",
+				Value: active,
+			},
+		},
+	}
+
+	var userResponse ScimUser
+	_, annos, err := c.doScimRequest(ctx, updateUserUrl.String(), http.MethodPatch, &userResponse, &patchData)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &userResponse, annos, nil
+}
@@ -0,0 +1,224 @@
+package miro
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+)
+
+type (
+	// UserGroup is the response from the GetUserGroups endpoint.
+	UserGroup struct {
+		Id          string `json:"id"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Type        string `json:"type"`
+	}
+	// GetUserGroupsResponse is the response from the GetUserGroups endpoint.
+	GetUserGroupsResponse struct {
+		Limit  int32       `json:"limit"`
+		Size   int32       `json:"size"`
+		Cursor string      `json:"cursor"`
+		Data   []UserGroup `json:"data"`
+		Type   string      `json:"type"`
+	}
+	// UserGroupMember is the response from the GetUserGroupMembers endpoint.
+	UserGroupMember struct {
+		Id    string `json:"id"`
+		Email string `json:"email"`
+		Type  string `json:"type"`
+	}
+	// GetUserGroupMembersResponse is the response from the GetUserGroupMembers endpoint.
+	GetUserGroupMembersResponse struct {
+		Limit  int32             `json:"limit"`
+		Size   int32             `json:"size"`
+		Cursor string            `json:"cursor"`
+		Data   []UserGroupMember `json:"data"`
+		Type   string            `json:"type"`
+	}
+	// CreateUserGroupBody is the body for the CreateUserGroup endpoint.
+	CreateUserGroupBody struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+	}
+	// GetUserGroupTeamsResponse is the response from the GetUserGroupTeams endpoint.
+	GetUserGroupTeamsResponse struct {
+		Limit  int32  `json:"limit"`
+		Size   int32  `json:"size"`
+		Cursor string `json:"cursor"`
+		Data   []Team `json:"data"`
+		Type   string `json:"type"`
+	}
+	// AddUserGroupMemberBody is the body for the AddUserGroupMember endpoint.
+	AddUserGroupMemberBody struct {
+		Email string `json:"email"`
+	}
+)
+
+const (
+	UserGroupsUrl       = "/v2/orgs/%s/groups"
+	UserGroupMembersUrl = "/v2/orgs/%s/groups/%s/members"
+	UserGroupTeamsUrl   = "/v2/orgs/%s/groups/%s/teams"
+)
+
+// GetUserGroups gets the user groups for a given organization.
+func (c *Client) GetUserGroups(ctx context.Context, organizationId string, cursor string, limit int32, opts ...ReqOpt) (*GetUserGroupsResponse, annotations.Annotations, error) {
+	userGroupsUrl, err := buildResourceURL(fmt.Sprintf(UserGroupsUrl, organizationId))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requestOpts := []ReqOpt{WithLimit(limit)}
+	if cursor != "" {
+		requestOpts = append(requestOpts, WithCursor(cursor))
+	}
+	requestOpts = append(requestOpts, opts...)
+
+	var userGroups GetUserGroupsResponse
+	_, annos, err := c.doRequest(ctx, userGroupsUrl.String(), http.MethodGet, &userGroups, nil, requestOpts...)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &userGroups, annos, nil
+}
+
+// CreateUserGroup creates a user group in a given organization.
+func (c *Client) CreateUserGroup(ctx context.Context, organizationId string, name string, description string) (*UserGroup, annotations.Annotations, error) {
+	userGroupsUrl, err := buildResourceURL(fmt.Sprintf(UserGroupsUrl, organizationId))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body := CreateUserGroupBody{
+		Name:        name,
+		Description: description,
+	}
+
+	var userGroup UserGroup
+	_, annos, err := c.doRequest(ctx, userGroupsUrl.String(), http.MethodPost, &userGroup, body)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &userGroup, annos, nil
+}
+
+// DeleteUserGroup deletes a user group from a given organization.
+func (c *Client) DeleteUserGroup(ctx context.Context, organizationId string, groupId string) (annotations.Annotations, error) {
+	userGroupUrl, err := buildResourceURL(fmt.Sprintf(UserGroupsUrl, organizationId), groupId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, annos, err := c.doRequest(ctx, userGroupUrl.String(), http.MethodDelete, nil, nil)
+	if err != nil {
+		return annos, err
+	}
+
+	return annos, nil
+}
+
+// GetUserGroupTeams gets the teams a given user group is assigned to.
+func (c *Client) GetUserGroupTeams(ctx context.Context, organizationId string, groupId string, cursor string, limit int32, opts ...ReqOpt) (*GetUserGroupTeamsResponse, annotations.Annotations, error) {
+	userGroupTeamsUrl, err := buildResourceURL(fmt.Sprintf(UserGroupTeamsUrl, organizationId, groupId))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requestOpts := []ReqOpt{WithLimit(limit)}
+	if cursor != "" {
+		requestOpts = append(requestOpts, WithCursor(cursor))
+	}
+	requestOpts = append(requestOpts, opts...)
+
+	var userGroupTeams GetUserGroupTeamsResponse
+	_, annos, err := c.doRequest(ctx, userGroupTeamsUrl.String(), http.MethodGet, &userGroupTeams, nil, requestOpts...)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &userGroupTeams, annos, nil
+}
+
+// GetUserGroupMembers gets the members of a given organization and user group.
+func (c *Client) GetUserGroupMembers(
+	ctx context.Context,
+	organizationId string,
+	groupId string,
+	cursor string,
+	limit int32,
+	opts ...ReqOpt,
+) (*GetUserGroupMembersResponse, annotations.Annotations, error) {
+	userGroupMembersUrl, err := buildResourceURL(fmt.Sprintf(UserGroupMembersUrl, organizationId, groupId))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requestOpts := []ReqOpt{WithLimit(limit)}
+	if cursor != "" {
+		requestOpts = append(requestOpts, WithCursor(cursor))
+	}
+	requestOpts = append(requestOpts, opts...)
+
+	var userGroupMembers GetUserGroupMembersResponse
+	_, annos, err := c.doRequest(ctx, userGroupMembersUrl.String(), http.MethodGet, &userGroupMembers, nil, requestOpts...)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &userGroupMembers, annos, nil
+}
+
+// GetUserGroupMember gets a single member of a given user group.
+func (c *Client) GetUserGroupMember(ctx context.Context, organizationId string, groupId string, memberId string) (*UserGroupMember, annotations.Annotations, error) {
+	userGroupMemberUrl, err := buildResourceURL(fmt.Sprintf(UserGroupMembersUrl, organizationId, groupId), memberId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var userGroupMember UserGroupMember
+	_, annos, err := c.doRequest(ctx, userGroupMemberUrl.String(), http.MethodGet, &userGroupMember, nil)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &userGroupMember, annos, nil
+}
+
+// AddUserGroupMember adds a user to a given user group.
+func (c *Client) AddUserGroupMember(ctx context.Context, organizationId string, groupId string, email string) (*UserGroupMember, annotations.Annotations, error) {
+	userGroupMembersUrl, err := buildResourceURL(fmt.Sprintf(UserGroupMembersUrl, organizationId, groupId))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body := AddUserGroupMemberBody{
+		Email: email,
+	}
+
+	var userGroupMember UserGroupMember
+	_, annos, err := c.doRequest(ctx, userGroupMembersUrl.String(), http.MethodPost, &userGroupMember, body)
+	if err != nil {
+		return nil, annos, err
+	}
+
+	return &userGroupMember, annos, nil
+}
+
+// RemoveUserGroupMember removes a member from a given user group.
+func (c *Client) RemoveUserGroupMember(ctx context.Context, organizationId string, groupId string, memberId string) (annotations.Annotations, error) {
+	userGroupMemberUrl, err := buildResourceURL(fmt.Sprintf(UserGroupMembersUrl, organizationId, groupId), memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, annos, err := c.doRequest(ctx, userGroupMemberUrl.String(), http.MethodDelete, nil, nil)
+	if err != nil {
+		return annos, err
+	}
+
+	return annos, nil
+}
@@ -33,7 +33,7 @@ type MockClient struct {
 	GetOrganizationMembersFunc func(ctx context.Context, organizationId string, cursor string, limit int32) (*miro.GetOrganizationMembersResponse, *http.Response, error)
 
 	// User methods (SCIM)
-	CreateUserFunc     func(ctx context.Context, user *miro.CreateUserRequest) (*miro.User, *http.Response, error)
+	CreateUserFunc     func(ctx context.Context, user *miro.CreateUserRequest) (*miro.ScimUser, *http.Response, error)
 	GetUserFunc        func(ctx context.Context, userId string) (*miro.ScimUser, *http.Response, error)
 	UpdateUserRoleFunc func(ctx context.Context, userId string, role string) (*miro.ScimUser, *http.Response, error)
 
@@ -90,7 +90,7 @@ func (m *MockClient) GetOrganizationMembers(ctx context.Context, organizationId
 }
 
 // CreateUser calls the mock method if it is defined.
-func (m *MockClient) CreateUser(ctx context.Context, user *miro.CreateUserRequest) (*miro.User, *http.Response, error) {
+func (m *MockClient) CreateUser(ctx context.Context, user *miro.CreateUserRequest) (*miro.ScimUser, *http.Response, error) {
 	if m.CreateUserFunc != nil {
 		return m.CreateUserFunc(ctx, user)
 	}
@@ -0,0 +1,104 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/conductorone/baton-miro/pkg/miro"
+)
+
+// MockResponse is a canned response served by the mock server.
+type MockResponse struct {
+	// Status is the HTTP status code, defaults to 200.
+	Status int
+	// File is the name of a JSON file in the mock directory used as the response body.
+	File string
+}
+
+// MockServer records the requests made against a mock Miro API.
+type MockServer struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+// Requests returns the "METHOD /path" keys of the requests received so far.
+func (s *MockServer) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+// Received reports whether the server received a request with the given "METHOD /path" key.
+func (s *MockServer) Received(key string) bool {
+	for _, request := range s.Requests() {
+		if request == key {
+			return true
+		}
+	}
+	return false
+}
+
+// NewMockServerClient returns a Miro client whose REST and SCIM requests are answered by a local server.
+// Each route is keyed by "METHOD /path", query parameters are ignored, and unknown routes return 404.
+func NewMockServerClient(t *testing.T, routes map[string]MockResponse) (*miro.Client, *MockServer) {
+	t.Helper()
+	t.Setenv("BATON_DISABLE_HTTP_CACHE", "true")
+
+	mockServer := &MockServer{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+
+		mockServer.mu.Lock()
+		mockServer.requests = append(mockServer.requests, key)
+		mockServer.mu.Unlock()
+
+		route, ok := routes[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		status := route.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if route.File != "" {
+			_, _ = w.Write([]byte(ReadFile(route.File)))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	serverUrl, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("failed to parse mock server URL: %v", err)
+	}
+
+	httpClient := &http.Client{
+		Transport: &rewriteTransport{target: serverUrl, base: server.Client().Transport},
+	}
+
+	return miro.New(httpClient, httpClient), mockServer
+}
+
+// rewriteTransport sends every request to the mock server, keeping its path and query.
+type rewriteTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+// RoundTrip rewrites the request host and forwards it to the mock server.
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rewritten := req.Clone(req.Context())
+	rewritten.URL.Scheme = t.target.Scheme
+	rewritten.URL.Host = t.target.Host
+	rewritten.Host = t.target.Host
+
+	return t.base.RoundTrip(rewritten)
+}